@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/config"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/migration"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/tagnorm"
+)
+
+// Firestoreの既存ドキュメントを移行する一回限りのコマンド
+//
+//	go run ./cmd/migrate -task=tags [-dry-run]
+//	go run ./cmd/migrate -task=timestamps [-dry-run]
+func main() {
+	task := flag.String("task", "", "実行する移行処理 (tags, timestamps)")
+	dryRun := flag.Bool("dry-run", false, "書き込みを行わず、更新対象の件数のみを表示する")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	// Firebaseの初期化
+	config.InitFirebase()
+	client := config.FirestoreClient
+	if client == nil {
+		log.Fatalf("Firestore client is not initialized")
+	}
+	defer client.Close()
+
+	switch *task {
+	case "tags":
+		// 記事・ユーザーのタグを正規のタグ名に揃える
+		dict, err := tagnorm.Load(config.LoadTagConfig().AliasesFile)
+		if err != nil {
+			log.Fatalf("failed to load tag dictionary: %v", err)
+		}
+		results, err := migration.NormalizeTags(ctx, client, dict, *dryRun)
+		for collection, res := range results {
+			log.Printf("%s: scanned %d documents, updated %d (dry-run: %t)", collection, res.Scanned, res.Updated, *dryRun)
+		}
+		if err != nil {
+			log.Fatalf("migration failed: %v", err)
+		}
+	case "timestamps":
+		// 記事の日時を文字列からFirestoreのタイムスタンプに変換する
+		res, err := migration.ConvertTimestamps(ctx, client, *dryRun)
+		log.Printf("articles: scanned %d documents, updated %d (dry-run: %t)", res.Scanned, res.Updated, *dryRun)
+		if err != nil {
+			log.Fatalf("migration failed: %v", err)
+		}
+	default:
+		log.Fatalf("unknown task %q (available: tags, timestamps)", *task)
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"time"
 
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/config"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/fetcher"
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/handler"
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/middleware"
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/repository"
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/service"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/tagnorm"
 
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
@@ -19,22 +21,127 @@ import (
 func main() {
 	ctx := context.Background()
 
-	// Firebaseの初期化
-	config.InitFirebase() // 引数なしで呼び出す
+	// リポジトリ層の初期化（STORAGEで保存先を選択）
+	var (
+		articleRepo repository.ArticleRepository
+		userRepo    repository.UserRepository
+		checkpoints fetcher.CheckpointStore
+		validators  fetcher.ValidatorStore
+	)
+	storageConfig := config.LoadStorageConfig()
+	switch storage := storageConfig.Backend; storage {
+	case config.StorageFirestore:
+		// Firebaseの初期化
+		config.InitFirebase() // 引数なしで呼び出す
+
+		// Firestoreクライアントをグローバル変数から取得
+		firestoreClient := config.FirestoreClient
+		if firestoreClient == nil {
+			log.Fatalf("Firestore client is not initialized") // 初期化失敗時のチェック
+		}
+
+		articleRepo = repository.NewArticleRepository(firestoreClient)
+		userRepo = repository.NewUserRepository(firestoreClient) // userRepoも初期化
+		checkpoints = repository.NewCheckpointRepository(firestoreClient)
+		validators = repository.NewValidatorRepository(firestoreClient)
+	case config.StorageMemory:
+		// データはメモリ上に保存し、Firebaseは設定されている場合のみ認証に使う（Authエミュレーターなど）
+		// 設定されていない場合、ユーザー関連APIは認証できないため利用できない
+		log.Println("Using in-memory storage. Data will be lost on restart.")
+		if config.FirebaseAuthConfigured() {
+			config.InitFirebaseAuth()
+		}
+		articleRepo = repository.NewMemoryArticleRepository()
+		userRepo = repository.NewMemoryUserRepository()
+		checkpoints = fetcher.NewMemoryCheckpointStore()
+		validators = fetcher.NewMemoryValidatorStore()
+	case config.StorageSQLite, config.StoragePostgres:
+		// データはSQLデータベースに保存し、Firebaseは設定されている場合のみ認証に使う
+		if config.FirebaseAuthConfigured() {
+			config.InitFirebaseAuth()
+		}
+		dialect := repository.SQLDialect(storage)
+		db, err := repository.OpenSQL(ctx, dialect, storageConfig.DatabaseURL)
+		if err != nil {
+			log.Fatalf("failed to open database: %v", err)
+		}
+		defer db.Close()
+
+		articleRepo = repository.NewSQLArticleRepository(db, dialect)
+		userRepo = repository.NewSQLUserRepository(db, dialect)
+		checkpoints = repository.NewSQLCheckpointRepository(db, dialect)
+		validators = repository.NewSQLValidatorRepository(db, dialect)
+	default:
+		log.Fatalf("unknown STORAGE %q (available: %s, %s, %s, %s)", storage,
+			config.StorageFirestore, config.StorageMemory, config.StorageSQLite, config.StoragePostgres)
+	}
 
-	// Firestoreクライアントをグローバル変数から取得
-	firestoreClient := config.FirestoreClient
-	if firestoreClient == nil {
-		log.Fatalf("Firestore client is not initialized") // 初期化失敗時のチェック
+	// 記事の取得元の初期化（FETCH_SOURCESで有効化・順序を指定）
+	fetcherConfig := config.LoadFetcherConfig()
+	var feeds []fetcher.FeedConfig
+	for _, f := range fetcherConfig.Feeds {
+		feeds = append(feeds, fetcher.FeedConfig{
+			Name:        f.Name,
+			URL:         f.URL,
+			Source:      f.Source,
+			DefaultTags: f.Tags,
+		})
+	}
+	fetchers, err := fetcher.NewDefaultRegistry(fetcher.Config{
+		HTTP: fetcher.HTTPConfig{
+			Client:     fetcher.NewHTTPClient(fetcherConfig.HTTPTimeout, fetcherConfig.MaxPerHost),
+			UserAgent:  fetcherConfig.UserAgent,
+			Validators: validators,
+		},
+		Qiita: fetcher.QiitaConfig{
+			MaxArticlesPerTag: fetcherConfig.QiitaMaxArticlesPerTag,
+			AccessToken:       fetcherConfig.QiitaAccessToken,
+			Incremental:       fetcherConfig.QiitaIncremental,
+			InitialWindow:     fetcherConfig.QiitaInitialWindow,
+			Checkpoints:       checkpoints,
+			BaseURL:           fetcherConfig.QiitaBaseURL,
+		},
+		Zenn: fetcher.ZennConfig{
+			BaseURL: fetcherConfig.ZennBaseURL,
+		},
+		Feeds: feeds,
+	}).Select(fetcherConfig.Sources)
+	if err != nil {
+		log.Fatalf("invalid fetcher configuration: %v", err)
 	}
 
-	// リポジトリ層の初期化
-	articleRepo := repository.NewArticleRepository(firestoreClient)
-	userRepo := repository.NewUserRepository(firestoreClient) // userRepoも初期化
+	// タグの正規化辞書の読み込み
+	tagDict, err := tagnorm.Load(config.LoadTagConfig().AliasesFile)
+	if err != nil {
+		log.Fatalf("failed to load tag dictionary: %v", err)
+	}
+
+	// 記事ページのOGP情報の取得（ホストごとの同時接続数は記事取得と同じ上限を使う）
+	// 記事のURLは外部のサービスが返すものなので、内部ネットワークのアドレスには接続しない
+	var enricher service.Enricher
+	if fetcherConfig.OGPEnabled {
+		ogpTimeout := fetcherConfig.OGPTimeout
+		if ogpTimeout <= 0 {
+			ogpTimeout = fetcher.DefaultOGPTimeout
+		}
+		enricher = fetcher.NewOGPEnricher(fetcher.OGPConfig{
+			Workers: fetcherConfig.OGPWorkers,
+			HTTP: fetcher.HTTPConfig{
+				Client:    fetcher.NewPublicHTTPClient(ogpTimeout, fetcherConfig.MaxPerHost),
+				UserAgent: fetcherConfig.UserAgent,
+			},
+		})
+	}
 
 	// サービス層の初期化
-	articleService := service.NewArticleService(articleRepo)
-	userService := service.NewUserService(userRepo)
+	articleService := service.NewArticleService(articleRepo, service.ArticleServiceConfig{
+		Fetchers: fetchers,
+		Workers:  fetcherConfig.Workers,
+		Tags:     tagDict,
+		Enricher: enricher,
+		Health:   service.NewHealthTracker(fetcherConfig.CircuitThreshold, fetcherConfig.CircuitCooldown),
+	})
+	userService := service.NewUserService(userRepo, tagDict)
 
 	// ★ サーバー起動時に一度だけ記事の取得と保存を実行 ★
 	// 注意: これは簡易実装です。本来は定期実行されるバッチ処理などで実行すべきです。
@@ -47,12 +154,21 @@ func main() {
 	fetchCtx, cancel := context.WithTimeout(ctx, 60*time.Second) // タイムアウトを設定
 	defer cancel()
 
-	err := articleService.FetchAndSaveArticles(fetchCtx, tagsToFetch)
+	result, err := articleService.FetchAndSaveArticles(fetchCtx, tagsToFetch)
 	if err != nil {
 		log.Printf("Warning: failed to fetch and save initial articles: %v", err) // エラーでもサーバーは起動させる
 	} else {
 		log.Println("Initial articles fetched and saved successfully.")
 	}
+	if result != nil {
+		log.Printf("Saved %d articles, %d failed", result.Saved, result.Failed)
+		for _, sr := range result.Sources {
+			log.Printf("Source %s: fetched %d articles, %d tags unchanged, %d tags failed, %d tags skipped", sr.Source, sr.Fetched, sr.Unchanged, sr.Failed, sr.Skipped)
+			if sr.RateLimit != nil {
+				log.Printf("Source %s: rate limit remaining %d (resets at %s)", sr.Source, sr.RateLimit.Remaining, sr.RateLimit.Reset.Format(time.RFC3339))
+			}
+		}
+	}
 
 	// Echoサーバーの初期化
 	e := echo.New()
@@ -60,13 +176,14 @@ func main() {
 	// CORSミドルウェアを追加
 	e.Use(echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
 		AllowOrigins: []string{"http://localhost:3000", "https://techee-front-end.vercel.app"}, // フロントエンドのオリジンを許可
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, middleware.HeaderAdminToken},
 		AllowMethods: []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodOptions},
 	}))
 
 	// ハンドラー層の初期化とルーティング設定
 	articleHandler := handler.NewArticleHandler(articleService) // ArticleServiceをハンドラーに渡す
 	userHandler := handler.NewUserHandler(userService)          // UserServiceをハンドラーに渡す
+	adminHandler := handler.NewAdminHandler(articleService)
 
 	// 記事一覧API
 	e.GET("/api/articles", articleHandler.GetArticles)
@@ -74,6 +191,11 @@ func main() {
 	// ユーザー関連API（認証ミドルウェア適用）
 	e.GET("/api/user", userHandler.GetUser, middleware.FirebaseAuth)
 	e.PUT("/api/user/tags", userHandler.UpdateUserTags, middleware.FirebaseAuth)
+	e.PUT("/api/user/lang", userHandler.UpdateUserLang, middleware.FirebaseAuth)
+
+	// 管理用API（ADMIN_TOKENによる認証。未設定の場合は常に拒否）
+	adminAuth := middleware.AdminToken(config.LoadAdminConfig().Token)
+	e.GET("/api/admin/sources", adminHandler.GetSourceHealth, adminAuth)
 
 	log.Println("Server started at :8080")
 	e.Logger.Fatal(e.Start(":8080"))
@@ -0,0 +1,18 @@
+package config
+
+import "os"
+
+// AdminConfig は管理用APIの設定です。
+type AdminConfig struct {
+	// Token は管理用APIへのアクセスに必要なトークンです。空の場合は管理用APIへのアクセスをすべて拒否します。
+	Token string
+}
+
+// LoadAdminConfig は環境変数から管理用APIの設定を読み込みます。
+//
+//	ADMIN_TOKEN: 管理用APIのトークン（X-Admin-Tokenヘッダーで指定する）
+func LoadAdminConfig() AdminConfig {
+	return AdminConfig{
+		Token: os.Getenv("ADMIN_TOKEN"),
+	}
+}
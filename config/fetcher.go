@@ -0,0 +1,199 @@
+package config
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FetcherConfig は記事取得処理の設定です。
+type FetcherConfig struct {
+	// Sources は有効にするソース名の一覧です。記載した順に実行されます。
+	// 空の場合は登録済みのすべてのソースを使用します。
+	Sources []string
+
+	// QiitaMaxArticlesPerTag はQiitaからタグごとに取得する最大記事数です。0の場合はデフォルト値を使用します。
+	QiitaMaxArticlesPerTag int
+	// QiitaAccessToken はQiita APIのアクセストークンです。
+	QiitaAccessToken string
+	// QiitaIncremental がtrueの場合、Qiitaの新着記事を前回の取得日時以降に絞って取得します。
+	QiitaIncremental bool
+	// QiitaInitialWindow は前回の取得日時がない場合に取得するQiitaの新着記事の期間です。
+	QiitaInitialWindow time.Duration
+	// QiitaBaseURL はQiita APIのベースURLです。空の場合はデフォルトを使用します。
+	QiitaBaseURL string
+	// ZennBaseURL はZennのベースURLです。空の場合はデフォルトを使用します。
+	ZennBaseURL string
+
+	// Workers はソース×タグの取得を並行して実行する数です。0の場合はデフォルトを使用します。
+	Workers int
+	// MaxPerHost はホストごとの同時リクエスト数の上限です。0の場合は制限しません。
+	MaxPerHost int
+
+	// HTTPTimeout は各ソースへのHTTPリクエストのタイムアウトです。0の場合はデフォルトを使用します。
+	HTTPTimeout time.Duration
+	// UserAgent は各ソースへのリクエストに付与するUser-Agentです。空の場合はデフォルトを使用します。
+	UserAgent string
+
+	// OGPEnabled がtrueの場合、取得した記事ページのOGP情報（サムネイルなど）を取得します。
+	OGPEnabled bool
+	// OGPWorkers はOGP情報を並行して取得する数です。0の場合はデフォルトを使用します。
+	OGPWorkers int
+	// OGPTimeout は記事ページ1件の取得のタイムアウトです。0の場合はデフォルトを使用します。
+	OGPTimeout time.Duration
+
+	// CircuitThreshold は取得を一時停止するまでのソースごとの連続失敗回数です。0の場合はデフォルトを使用します。
+	CircuitThreshold int
+	// CircuitCooldown は連続して失敗したソースの取得を停止する時間です。0の場合はデフォルトを使用します。
+	CircuitCooldown time.Duration
+
+	// Feeds は汎用フィードソースの一覧です。
+	Feeds []FeedSource
+}
+
+// FeedSource は汎用フィードソース（RSS 2.0 / Atom）の設定です。
+type FeedSource struct {
+	Name   string   `json:"name"`   // ソース名（FETCH_SOURCESでの指定に使う）
+	URL    string   `json:"url"`    // フィードのURL
+	Source string   `json:"source"` // 記事に表示するソース名（例: "Hatena Blog"）
+	Tags   []string `json:"tags"`   // すべての記事に付与するタグ
+}
+
+// LoadFetcherConfig は環境変数から記事取得処理の設定を読み込みます。
+//
+//	FETCH_SOURCES: 有効にするソースをカンマ区切りで指定（例: "zenn,qiita"）
+//	QIITA_MAX_ARTICLES_PER_TAG: Qiitaからタグごとに取得する最大記事数
+//	QIITA_ACCESS_TOKEN: Qiita APIのアクセストークン（未設定の場合は未認証でアクセス）
+//	QIITA_INCREMENTAL: "false"の場合、Qiitaの新着記事の取得を行わない（デフォルト: true）
+//	QIITA_INITIAL_WINDOW: 初回に取得するQiitaの新着記事の期間（例: "168h"）
+//	QIITA_BASE_URL, ZENN_BASE_URL: 各ソースのベースURLの上書き
+//	FETCH_WORKERS: ソース×タグの取得を並行して実行する数
+//	FETCH_MAX_PER_HOST: ホストごとの同時リクエスト数の上限（デフォルト: 2）
+//	FETCH_HTTP_TIMEOUT: HTTPリクエストのタイムアウト（例: "15s"）
+//	FETCH_USER_AGENT: リクエストに付与するUser-Agent
+//	OGP_ENABLED: "false"の場合、記事ページのOGP情報を取得しない（デフォルト: true）
+//	OGP_WORKERS: OGP情報を並行して取得する数
+//	OGP_TIMEOUT: 記事ページ1件の取得のタイムアウト（例: "5s"）
+//	CIRCUIT_BREAKER_THRESHOLD: ソースの取得を一時停止するまでの連続失敗回数（デフォルト: 3）
+//	CIRCUIT_BREAKER_COOLDOWN: 取得を一時停止する時間（例: "10m"）
+//	FEED_SOURCES: 汎用フィードソースのJSON配列
+//	  （例: [{"name":"mercari","url":"https://engineering.mercari.com/blog/feed.xml","source":"Mercari Engineering","tags":["Go"]}]）
+func LoadFetcherConfig() FetcherConfig {
+	return FetcherConfig{
+		Sources:                splitList(os.Getenv("FETCH_SOURCES")),
+		QiitaMaxArticlesPerTag: intEnv("QIITA_MAX_ARTICLES_PER_TAG", 0),
+		QiitaAccessToken:       os.Getenv("QIITA_ACCESS_TOKEN"),
+		QiitaIncremental:       boolEnv("QIITA_INCREMENTAL", true),
+		QiitaInitialWindow:     durationEnv("QIITA_INITIAL_WINDOW", 0),
+		QiitaBaseURL:           os.Getenv("QIITA_BASE_URL"),
+		ZennBaseURL:            os.Getenv("ZENN_BASE_URL"),
+		Workers:                intEnv("FETCH_WORKERS", 0),
+		MaxPerHost:             intEnv("FETCH_MAX_PER_HOST", 2),
+		HTTPTimeout:            durationEnv("FETCH_HTTP_TIMEOUT", 0),
+		UserAgent:              os.Getenv("FETCH_USER_AGENT"),
+		OGPEnabled:             boolEnv("OGP_ENABLED", true),
+		OGPWorkers:             intEnv("OGP_WORKERS", 0),
+		OGPTimeout:             durationEnv("OGP_TIMEOUT", 0),
+		CircuitThreshold:       intEnv("CIRCUIT_BREAKER_THRESHOLD", 0),
+		CircuitCooldown:        durationEnv("CIRCUIT_BREAKER_COOLDOWN", 0),
+		Feeds:                  loadFeedSources(os.Getenv("FEED_SOURCES")),
+	}
+}
+
+// builtinSourceNames は組み込みソースの名前です。フィードソースの名前には使えません。
+var builtinSourceNames = []string{"qiita", "zenn", "hatena", "devto", "hackernews"}
+
+// FEED_SOURCESのJSONを読み込む。不正な値の場合はフィードソースなしとして扱う
+// 組み込みソースや他のフィードと同じ名前（大文字小文字は区別しない）のエントリと、
+// 記事のドキュメントIDに使えない"/"を含む名前のエントリはスキップする
+func loadFeedSources(v string) []FeedSource {
+	if v == "" {
+		return nil
+	}
+	var feeds []FeedSource
+	if err := json.Unmarshal([]byte(v), &feeds); err != nil {
+		log.Printf("invalid value for FEED_SOURCES: %v, ignoring feed sources", err)
+		return nil
+	}
+	valid := feeds[:0]
+	seen := make(map[string]bool)
+	for _, f := range feeds {
+		if f.Name == "" || f.URL == "" {
+			log.Printf("FEED_SOURCES entry requires name and url, skipping: %+v", f)
+			continue
+		}
+		if strings.Contains(f.Name, "/") {
+			log.Printf("FEED_SOURCES entry %q must not contain \"/\", skipping", f.Name)
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(f.Name))
+		if slices.Contains(builtinSourceNames, name) {
+			log.Printf("FEED_SOURCES entry %q conflicts with a built-in source, skipping", f.Name)
+			continue
+		}
+		if seen[name] {
+			log.Printf("FEED_SOURCES entry %q is duplicated, skipping", f.Name)
+			continue
+		}
+		seen[name] = true
+		valid = append(valid, f)
+	}
+	return valid
+}
+
+// 整数の環境変数を読み込む。未設定または不正な値の場合はデフォルト値を返す
+func intEnv(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("invalid value for %s: %q, using default %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+// 真偽値の環境変数を読み込む。未設定または不正な値の場合はデフォルト値を返す
+func boolEnv(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("invalid value for %s: %q, using default %t", key, v, def)
+		return def
+	}
+	return b
+}
+
+// 時間の環境変数（例: "15s"）を読み込む。未設定または不正な値の場合はデフォルト値を返す
+func durationEnv(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("invalid value for %s: %q, using default %s", key, v, def)
+		return def
+	}
+	return d
+}
+
+// カンマ区切りの文字列を分割し、空要素を取り除く
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
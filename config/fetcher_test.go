@@ -0,0 +1,23 @@
+package config
+
+import "testing"
+
+func TestLoadFeedSources(t *testing.T) {
+	v := `[
+		{"name":"mercari","url":"https://engineering.mercari.com/blog/feed.xml"},
+		{"name":"Zenn","url":"https://example.com/zenn.xml"},
+		{"name":"MERCARI","url":"https://example.com/other.xml"},
+		{"name":"cybozu","url":"https://blog.cybozu.io/feed"},
+		{"name":"team/blog","url":"https://example.com/team.xml"},
+		{"name":"nourl"}
+	]`
+
+	feeds := loadFeedSources(v)
+	var names []string
+	for _, f := range feeds {
+		names = append(names, f.Name)
+	}
+	if len(names) != 2 || names[0] != "mercari" || names[1] != "cybozu" {
+		t.Errorf("loadFeedSources() names = %v, want [mercari cybozu]", names)
+	}
+}
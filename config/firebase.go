@@ -19,6 +19,55 @@ var (
 func InitFirebase() {
 	ctx := context.Background()
 
+	app := newFirebaseApp(ctx)
+	FirebaseApp = app
+
+	// Firestoreクライアントの初期化
+	client, err := app.Firestore(ctx)
+	if err != nil {
+		log.Fatalf("failed to initialize firestore: %v", err)
+	}
+	FirestoreClient = client
+}
+
+// InitFirebaseAuth はFirestoreを使わずに、認証のためのFirebase Appのみを初期化します。
+// データをSQLデータベースに保存する場合に使用します。
+func InitFirebaseAuth() {
+	FirebaseApp = newFirebaseApp(context.Background())
+}
+
+// FirebaseAuthConfigured はFirestoreを使わない場合に、認証のためのFirebase Appを初期化する設定があるかを返します。
+// FIREBASE_PROJECT_ID（本番）またはFIREBASE_AUTH_EMULATOR_HOST（エミュレーター）が設定されている場合にtrueを返します。
+func FirebaseAuthConfigured() bool {
+	return os.Getenv("FIREBASE_PROJECT_ID") != "" || os.Getenv("FIREBASE_AUTH_EMULATOR_HOST") != ""
+}
+
+// DefaultEmulatorProjectID はエミュレーターに接続する場合のデフォルトのプロジェクトIDです。
+// "demo-" で始まるプロジェクトIDはエミュレーター専用として扱われ、本番のリソースにアクセスしません。
+const DefaultEmulatorProjectID = "demo-techee"
+
+// usingEmulator はFirestoreまたはFirebase Authのエミュレーターの接続先が設定されているかを返します。
+// 接続先はSDKが環境変数から読み込むため、ここでは認証情報を不要にするかの判定にのみ使います。
+func usingEmulator() bool {
+	return os.Getenv("FIRESTORE_EMULATOR_HOST") != "" || os.Getenv("FIREBASE_AUTH_EMULATOR_HOST") != ""
+}
+
+func newFirebaseApp(ctx context.Context) *firebase.App {
+	// エミュレーターに接続する場合は認証情報なしで初期化
+	if usingEmulator() {
+		projectID := os.Getenv("FIREBASE_PROJECT_ID")
+		if projectID == "" {
+			projectID = DefaultEmulatorProjectID
+		}
+		log.Printf("Using Firebase emulators (firestore: %q, auth: %q, project: %s)",
+			os.Getenv("FIRESTORE_EMULATOR_HOST"), os.Getenv("FIREBASE_AUTH_EMULATOR_HOST"), projectID)
+		app, err := firebase.NewApp(ctx, &firebase.Config{ProjectID: projectID}, option.WithoutAuthentication())
+		if err != nil {
+			log.Fatalf("failed to initialize firebase app for emulators: %v", err)
+		}
+		return app
+	}
+
 	// 環境変数からプロジェクトIDを取得
 	projectID := os.Getenv("FIREBASE_PROJECT_ID")
 	if projectID == "" {
@@ -42,12 +91,5 @@ func InitFirebase() {
 	if err != nil {
 		log.Fatalf("failed to initialize firebase app: %v", err)
 	}
-	FirebaseApp = app
-
-	// Firestoreクライアントの初期化
-	client, err := app.Firestore(ctx)
-	if err != nil {
-		log.Fatalf("failed to initialize firestore: %v", err)
-	}
-	FirestoreClient = client
+	return app
 }
@@ -0,0 +1,18 @@
+package config
+
+import "os"
+
+// TagConfig はタグの正規化の設定です。
+type TagConfig struct {
+	// AliasesFile は組み込みの辞書に追加するタグの別名のJSONファイルのパスです。空の場合は組み込みの辞書のみを使用します。
+	AliasesFile string
+}
+
+// LoadTagConfig は環境変数からタグの正規化の設定を読み込みます。
+//
+//	TAG_ALIASES_FILE: タグの別名のJSONファイル（例: {"Go": ["golang", "go言語"]}）
+func LoadTagConfig() TagConfig {
+	return TagConfig{
+		AliasesFile: os.Getenv("TAG_ALIASES_FILE"),
+	}
+}
@@ -0,0 +1,42 @@
+package fetcher
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CheckpointStore はソース・タグごとに前回の取得に成功した日時を保存するインターフェースです。
+type CheckpointStore interface {
+	// LastFetched は前回の取得日時を返します。記録がない場合はfalseを返します。
+	LastFetched(ctx context.Context, source, tag string) (time.Time, bool, error)
+	// SetLastFetched は取得日時を記録します。
+	SetLastFetched(ctx context.Context, source, tag string, t time.Time) error
+}
+
+// MemoryCheckpointStore はメモリ上に取得日時を保存するCheckpointStoreの実装です。
+type MemoryCheckpointStore struct {
+	mu          sync.RWMutex
+	checkpoints map[string]time.Time
+}
+
+// NewMemoryCheckpointStore はMemoryCheckpointStoreの新しいインスタンスを作成します。
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: make(map[string]time.Time)}
+}
+
+// LastFetched は前回の取得日時を返します。
+func (s *MemoryCheckpointStore) LastFetched(ctx context.Context, source, tag string) (time.Time, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.checkpoints[source+"\x00"+tag]
+	return t, ok, nil
+}
+
+// SetLastFetched は取得日時を記録します。
+func (s *MemoryCheckpointStore) SetLastFetched(ctx context.Context, source, tag string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[source+"\x00"+tag] = t
+	return nil
+}
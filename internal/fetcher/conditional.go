@@ -0,0 +1,150 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// ErrNotModified は前回の取得から内容が変わっていない（HTTP 304）ことを表します。
+// この場合、Fetcherは記事を返さず、呼び出し元は保存処理を省略できます。
+var ErrNotModified = errors.New("not modified since last fetch")
+
+// Validators はHTTPの条件付きリクエストに使うレスポンスの検証子です。
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// ValidatorStore はリクエストURLごとの検証子を保存するインターフェースです。
+type ValidatorStore interface {
+	// Get はURLに対応する検証子を返します。記録がない場合はfalseを返します。
+	Get(ctx context.Context, url string) (Validators, bool, error)
+	// Set はURLに対応する検証子を保存します。
+	Set(ctx context.Context, url string, v Validators) error
+}
+
+// MemoryValidatorStore はメモリ上に検証子を保存するValidatorStoreの実装です。
+type MemoryValidatorStore struct {
+	mu         sync.RWMutex
+	validators map[string]Validators
+}
+
+// NewMemoryValidatorStore はMemoryValidatorStoreの新しいインスタンスを作成します。
+func NewMemoryValidatorStore() *MemoryValidatorStore {
+	return &MemoryValidatorStore{validators: make(map[string]Validators)}
+}
+
+// Get はURLに対応する検証子を返します。
+func (s *MemoryValidatorStore) Get(ctx context.Context, url string) (Validators, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.validators[url]
+	return v, ok, nil
+}
+
+// Set はURLに対応する検証子を保存します。
+func (s *MemoryValidatorStore) Set(ctx context.Context, url string, v Validators) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.validators[url] = v
+	return nil
+}
+
+// pendingValidators は記事の保存が終わるまで保存を保留している検証子です。
+type pendingValidators struct {
+	mu         sync.Mutex
+	validators map[string]Validators
+	// discarded は今回の実行で取得に失敗したFetchが受け取ったURLです。
+	// 同じURLを使った別のFetchが成功しても、失敗したFetchの記事は保存されないため検証子を保存しない
+	discarded map[string]bool
+}
+
+// validatorBatch は1回のFetchで受け取ったレスポンスの検証子です。
+// Fetchが成功した場合のみkeepValidatorsで保存の対象にします。
+type validatorBatch map[string]Validators
+
+// record はレスポンスの検証子を記録します。レスポンスを正常に処理できた後に呼び出します。
+func (b validatorBatch) record(url string, header http.Header) {
+	b.add(url, Validators{ETag: header.Get("ETag"), LastModified: header.Get("Last-Modified")})
+}
+
+func (b validatorBatch) add(url string, v Validators) {
+	if v.ETag == "" && v.LastModified == "" {
+		return
+	}
+	b[url] = v
+}
+
+// 保存済みの検証子があれば、条件付きリクエストのヘッダーを設定する
+// 検証子を読めない場合は、通常のリクエストとして続行する
+func (r requester) setConditionalHeaders(ctx context.Context, url string, header http.Header) {
+	if r.validators == nil {
+		return
+	}
+	v, ok, err := r.validators.Get(ctx, url)
+	if err != nil {
+		log.Printf("failed to get validators for %s: %v", url, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	if v.ETag != "" {
+		header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		header.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+// saveValidators は1回のリクエストで完了するFetchのために、レスポンスの検証子を保存の対象にします。
+// レスポンスを正常に処理できた後に呼び出します。
+func (r requester) saveValidators(url string, header http.Header) {
+	b := validatorBatch{}
+	b.record(url, header)
+	r.keepValidators(b, nil)
+}
+
+// keepValidators はFetchの結果に応じて、Fetchが受け取った検証子を扱います。
+// 成功した場合（変更なしを含む）は検証子を保存の対象にし、記事の保存に成功した後にcommitValidatorsで保存します。
+// 失敗した場合は、取得し直した記事が次回も返されるよう、同じURLの検証子を今回の実行では保存しません。
+func (r requester) keepValidators(b validatorBatch, err error) {
+	if r.validators == nil || len(b) == 0 {
+		return
+	}
+	r.pending.mu.Lock()
+	defer r.pending.mu.Unlock()
+	failed := err != nil && !errors.Is(err, ErrNotModified)
+	for url, v := range b {
+		if failed {
+			r.pending.discarded[url] = true
+			delete(r.pending.validators, url)
+			continue
+		}
+		if !r.pending.discarded[url] {
+			r.pending.validators[url] = v
+		}
+	}
+}
+
+// commitValidators は記録した検証子をストアに保存します。保存できなかった検証子は次回の保存まで残します。
+func (r requester) commitValidators(ctx context.Context) error {
+	if r.validators == nil {
+		return nil
+	}
+	r.pending.mu.Lock()
+	defer r.pending.mu.Unlock()
+	clear(r.pending.discarded)
+	var errs []error
+	for url, v := range r.pending.validators {
+		if err := r.validators.Set(ctx, url, v); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(r.pending.validators, url)
+	}
+	return errors.Join(errs...)
+}
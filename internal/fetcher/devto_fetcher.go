@@ -0,0 +1,182 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/langdetect"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+const (
+	// DefaultDevToBaseURL はdev.to (Forem) APIのベースURLです。
+	DefaultDevToBaseURL = "https://dev.to/api"
+	// DefaultDevToMaxArticlesPerTag はタグごとに取得する記事数のデフォルト値です。
+	DefaultDevToMaxArticlesPerTag = 30
+	// DefaultDevToTopDays は人気記事の集計対象とする日数のデフォルト値です。
+	DefaultDevToTopDays = 30
+
+	devToMaxPerPage = 1000 // Forem APIのper_pageの上限
+)
+
+// dev.to API レスポンスの構造体を定義
+type devToArticle struct {
+	ID                     int      `json:"id"`
+	Title                  string   `json:"title"`
+	Description            string   `json:"description"` // 記事の概要（一覧APIには本文が含まれない）
+	URL                    string   `json:"url"`
+	PositiveReactionsCount int      `json:"positive_reactions_count"`
+	PublishedAt            string   `json:"published_at"` // ISO 8601 format
+	TagList                []string `json:"tag_list"`
+	User                   struct {
+		Username     string `json:"username"`
+		Name         string `json:"name"`
+		ProfileImage string `json:"profile_image"`
+	} `json:"user"`
+}
+
+// DevToConfig はDevToFetcherの設定です。
+type DevToConfig struct {
+	// MaxArticlesPerTag はタグごとに取得する最大記事数です。0以下の場合はデフォルト値を使用します。
+	MaxArticlesPerTag int
+	// TopDays は直近何日間の人気記事を取得するかです。0以下の場合はデフォルト値を使用します。
+	TopDays int
+	// BaseURL はAPIのベースURLです。空の場合はDefaultDevToBaseURLを使用します。
+	BaseURL string
+	// HTTP はHTTPクライアントの設定です。
+	HTTP HTTPConfig
+}
+
+// DevToFetcher はdev.to (Forem) APIから英語の記事を取得するFetcherです。
+type DevToFetcher struct {
+	maxPerTag int
+	topDays   int
+	baseURL   string
+	http      requester
+}
+
+// NewDevToFetcher はDevToFetcherの新しいインスタンスを作成します。
+func NewDevToFetcher(cfg DevToConfig) *DevToFetcher {
+	maxPerTag := cfg.MaxArticlesPerTag
+	if maxPerTag <= 0 {
+		maxPerTag = DefaultDevToMaxArticlesPerTag
+	}
+	topDays := cfg.TopDays
+	if topDays <= 0 {
+		topDays = DefaultDevToTopDays
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultDevToBaseURL
+	}
+	return &DevToFetcher{
+		maxPerTag: min(maxPerTag, devToMaxPerPage),
+		topDays:   topDays,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		http:      newRequester(cfg.HTTP),
+	}
+}
+
+// Name はソース名を返します。
+func (f *DevToFetcher) Name() string {
+	return "devto"
+}
+
+// Commit は記事の保存に成功した後に呼び出し、取得したレスポンスの検証子を保存します。
+func (f *DevToFetcher) Commit(ctx context.Context) error {
+	return f.http.commitValidators(ctx)
+}
+
+// Fetch は指定したタグの直近の人気記事を取得します。
+func (f *DevToFetcher) Fetch(ctx context.Context, tag string) ([]model.Article, error) {
+	// クエリパラメータを設定
+	params := url.Values{}
+	params.Add("top", strconv.Itoa(f.topDays)) // 直近N日間の人気順
+	params.Add("per_page", strconv.Itoa(f.maxPerTag))
+	if tag != "" {
+		// dev.toのタグは小文字の英数字のみ（例: "Go" -> "go"）
+		params.Add("tag", strings.ToLower(tag))
+	}
+	reqURL := f.baseURL + "/articles?" + params.Encode()
+
+	res, err := f.http.get(ctx, reqURL, http.Header{"Accept": {"application/vnd.forem.api-v1+json"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch dev.to articles: %w", err)
+	}
+	defer res.Body.Close()
+
+	// 前回の取得から変更がない
+	if res.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+
+	// ステータスコードを確認
+	if res.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("dev.to API returned non-200 status: %d, body: %s", res.StatusCode, bodyBytes)
+	}
+
+	// JSONをパース
+	var devToArticles []devToArticle
+	if err := json.NewDecoder(res.Body).Decode(&devToArticles); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dev.to API response: %w", err)
+	}
+	f.http.saveValidators(reqURL, res.Header)
+
+	// 内部モデルにマッピング
+	articles := make([]model.Article, 0, len(devToArticles))
+	for _, da := range devToArticles {
+		// 検索は完全一致のため、取得に使ったタグの表記に揃えて付与する
+		var tags []string
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+		tags = mergeTags(tags, da.TagList)
+
+		var publishedAt time.Time
+		if t, err := time.Parse(time.RFC3339, da.PublishedAt); err == nil {
+			publishedAt = t
+		}
+
+		// 一覧APIは本文を返さないため、説明文を抜粋とし、語数は設定しない
+		excerpt, _ := makeExcerpt(da.Description)
+
+		articles = append(articles, model.Article{
+			ID:          "devto-" + strconv.Itoa(da.ID),
+			Title:       da.Title,
+			URL:         da.URL,
+			Tags:        tags,
+			Likes:       da.PositiveReactionsCount,
+			PublishedAt: publishedAt,
+			Source:      "DEV Community",
+			Lang:        langdetect.English,
+			Excerpt:     excerpt,
+			Author:      devToAuthor(da),
+		})
+	}
+	return articles, nil
+}
+
+// dev.toのユーザー情報を著者にマッピングする
+func devToAuthor(da devToArticle) *model.Author {
+	if da.User.Username == "" {
+		return nil
+	}
+	name := da.User.Name
+	if name == "" {
+		name = da.User.Username
+	}
+	return &model.Author{
+		ID:         da.User.Username,
+		Name:       name,
+		AvatarURL:  da.User.ProfileImage,
+		ProfileURL: "https://dev.to/" + da.User.Username,
+	}
+}
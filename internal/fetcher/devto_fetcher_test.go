@@ -0,0 +1,147 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newDevToTestServer(t *testing.T) (*httptest.Server, func() []*http.Request) {
+	t.Helper()
+	body, err := os.ReadFile("testdata/devto_articles_go.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r)
+		mu.Unlock()
+		if r.URL.Path != "/articles" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"devto-v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"devto-v1"`)
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []*http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(requests)
+	}
+}
+
+func TestDevToFetcherFetch(t *testing.T) {
+	srv, requests := newDevToTestServer(t)
+	f := NewDevToFetcher(DevToConfig{BaseURL: srv.URL, MaxArticlesPerTag: 20, TopDays: 7})
+
+	articles, err := f.Fetch(context.Background(), "Go")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	// タグは小文字にして指定する
+	reqs := requests()
+	if len(reqs) != 1 {
+		t.Fatalf("got %d requests, want 1", len(reqs))
+	}
+	q := reqs[0].URL.Query()
+	if q.Get("tag") != "go" || q.Get("top") != "7" || q.Get("per_page") != "20" {
+		t.Errorf("query params = %v, want tag=go top=7 per_page=20", q)
+	}
+	if got := reqs[0].Header.Get("Accept"); got != "application/vnd.forem.api-v1+json" {
+		t.Errorf("Accept = %q", got)
+	}
+
+	if len(articles) != 2 {
+		t.Fatalf("Fetch() returned %d articles, want 2", len(articles))
+	}
+	a := articles[0]
+	if a.ID != "devto-1912345" || a.URL != "https://dev.to/gopher/understanding-go-generics-in-10-minutes-3k2a" || a.Likes != 245 {
+		t.Errorf("article = {%q %q %d}", a.ID, a.URL, a.Likes)
+	}
+	// 取得に使ったタグの表記を先頭にし、重複するタグは除く
+	if want := []string{"Go", "generics", "programming"}; !slices.Equal(a.Tags, want) {
+		t.Errorf("Tags = %v, want %v", a.Tags, want)
+	}
+	if !a.PublishedAt.Equal(time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("PublishedAt = %v", a.PublishedAt)
+	}
+	if a.Source != "DEV Community" || a.Lang != "en" {
+		t.Errorf("Source/Lang = %q/%q", a.Source, a.Lang)
+	}
+	if a.Excerpt != "A quick tour of type parameters &amp; constraints in Go 1.18+." || a.WordCount != 0 {
+		t.Errorf("Excerpt/WordCount = %q/%d", a.Excerpt, a.WordCount)
+	}
+	if a.Author == nil || a.Author.Name != "Gopher Dev" || a.Author.ProfileURL != "https://dev.to/gopher" || a.Author.AvatarURL != "https://media.dev.to/profile/gopher.png" {
+		t.Errorf("Author = %+v", a.Author)
+	}
+
+	// 日時をパースできない記事はゼロ値、名前のないユーザーはユーザー名を使う
+	b := articles[1]
+	if !b.PublishedAt.IsZero() {
+		t.Errorf("PublishedAt = %v, want zero", b.PublishedAt)
+	}
+	if b.Author == nil || b.Author.Name != "anon" {
+		t.Errorf("Author = %+v, want name anon", b.Author)
+	}
+}
+
+func TestDevToFetcherWithoutTag(t *testing.T) {
+	srv, requests := newDevToTestServer(t)
+	f := NewDevToFetcher(DevToConfig{BaseURL: srv.URL})
+
+	articles, err := f.Fetch(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if q := requests()[0].URL.Query(); q.Has("tag") {
+		t.Errorf("query has tag = %q, want none", q.Get("tag"))
+	}
+	if want := []string{"go", "generics", "programming"}; !slices.Equal(articles[0].Tags, want) {
+		t.Errorf("Tags = %v, want %v", articles[0].Tags, want)
+	}
+}
+
+func TestDevToFetcherNotModified(t *testing.T) {
+	ctx := context.Background()
+	srv, requests := newDevToTestServer(t)
+	validators := NewMemoryValidatorStore()
+	f := NewDevToFetcher(DevToConfig{BaseURL: srv.URL, HTTP: HTTPConfig{Validators: validators}})
+
+	if _, err := f.Fetch(ctx, "Go"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	// 記事の保存（Commit）までは検証子を保存しない
+	if _, err := f.Fetch(ctx, "Go"); err != nil {
+		t.Fatalf("Fetch() before Commit error = %v, want articles", err)
+	}
+	if err := f.Commit(ctx); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	v, ok, err := validators.Get(ctx, srv.URL+requests()[0].URL.RequestURI())
+	if err != nil || !ok || v.ETag != `"devto-v1"` {
+		t.Fatalf("saved validators = %+v, %v, %v, want ETag \"devto-v1\"", v, ok, err)
+	}
+
+	if _, err := f.Fetch(ctx, "Go"); !errors.Is(err, ErrNotModified) {
+		t.Fatalf("Fetch() after Commit error = %v, want ErrNotModified", err)
+	}
+	reqs := requests()
+	if got := reqs[len(reqs)-1].Header.Get("If-None-Match"); got != `"devto-v1"` {
+		t.Errorf("If-None-Match = %q, want \"devto-v1\"", got)
+	}
+}
@@ -0,0 +1,115 @@
+package fetcher
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// ExcerptLength は抜粋の最大文字数です。
+const ExcerptLength = 200
+
+// Markdownの記法を取り除くための正規表現
+var (
+	mdFencedCode = regexp.MustCompile("(?ms)^\\s*(```|~~~).*?^\\s*(```|~~~)[^\\n]*$")
+	mdImage      = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	mdLink       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdInlineCode = regexp.MustCompile("`([^`]*)`")
+	mdHeading    = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s*`)
+	mdQuote      = regexp.MustCompile(`(?m)^\s*>\s?`)
+	mdListMarker = regexp.MustCompile(`(?m)^\s*([-*+]|\d+\.)\s+`)
+	mdBlockNote  = regexp.MustCompile(`(?m)^\s*:::.*$`) // Qiita/Zennのnote記法
+	mdEmphasis   = regexp.MustCompile(`(\*{1,3}|_{2,3}|~~)`)
+	mdHTMLTag    = regexp.MustCompile(`<[^>]+>`)
+	mdTableRule  = regexp.MustCompile(`(?m)^\s*\|?\s*:?-{3,}.*$`)
+)
+
+// excerptFromMarkdown はMarkdownの本文から、コードブロックや画像を除いたプレーンテキストの抜粋と語数を返します。
+func excerptFromMarkdown(md string) (string, int) {
+	text := mdFencedCode.ReplaceAllString(md, " ")
+	text = mdImage.ReplaceAllString(text, " ")
+	text = mdLink.ReplaceAllString(text, "$1")
+	text = mdInlineCode.ReplaceAllString(text, "$1")
+	text = mdBlockNote.ReplaceAllString(text, " ")
+	text = mdTableRule.ReplaceAllString(text, " ")
+	text = mdHeading.ReplaceAllString(text, "")
+	text = mdQuote.ReplaceAllString(text, "")
+	text = mdListMarker.ReplaceAllString(text, "")
+	text = mdEmphasis.ReplaceAllString(text, "")
+	text = mdHTMLTag.ReplaceAllString(text, " ")
+	text = strings.ReplaceAll(text, "|", " ")
+	return makeExcerpt(html.UnescapeString(text))
+}
+
+// excerptFromHTML はHTMLの本文から、コードブロックや画像を除いたプレーンテキストの抜粋と語数を返します。
+func excerptFromHTML(s string) (string, int) {
+	var b strings.Builder
+	skip := 0 // pre/script/styleの中にいる深さ
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// 終端（またはパースできない箇所）まで読んだ
+			return makeExcerpt(b.String())
+		case html.StartTagToken:
+			if name, _ := z.TagName(); isSkippedElement(string(name)) {
+				skip++
+			}
+			b.WriteByte(' ')
+		case html.EndTagToken:
+			if name, _ := z.TagName(); isSkippedElement(string(name)) && skip > 0 {
+				skip--
+			}
+			b.WriteByte(' ')
+		case html.TextToken:
+			if skip == 0 {
+				b.Write(z.Text())
+			}
+		}
+	}
+}
+
+// 抜粋に含めない要素（コードブロックなど）
+func isSkippedElement(name string) bool {
+	switch name {
+	case "pre", "script", "style", "figure":
+		return true
+	}
+	return false
+}
+
+// プレーンテキストの空白をまとめ、最大ExcerptLength文字の抜粋と語数を返す
+func makeExcerpt(text string) (string, int) {
+	text = strings.Join(strings.Fields(text), " ")
+	words := countWords(text)
+	if utf8.RuneCountInString(text) <= ExcerptLength {
+		return text, words
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:ExcerptLength])) + "…", words
+}
+
+// countWords は語数を数えます。
+// 英語などは空白・記号で区切られた単語を1語、日本語（漢字・ひらがな・カタカナ）は1文字を1語として数えます。
+func countWords(text string) int {
+	count := 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			count++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				count++
+				inWord = true
+			}
+		default:
+			inWord = false
+		}
+	}
+	return count
+}
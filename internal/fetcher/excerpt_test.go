@@ -0,0 +1,146 @@
+package fetcher
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestExcerptFromMarkdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		md        string
+		want      string
+		wantWords int
+	}{
+		{
+			name:      "コードブロックを除く",
+			md:        "前文\n\n```go\nfmt.Println(\"hello\")\n```\n\n後文",
+			want:      "前文 後文",
+			wantWords: 4,
+		},
+		{
+			name:      "画像を除きリンクはテキストを残す",
+			md:        "![図](https://example.com/a.png)\n詳しくは[公式サイト](https://go.dev)を参照",
+			want:      "詳しくは公式サイトを参照",
+			wantWords: 12,
+		},
+		{
+			name:      "見出し・強調・インラインコード",
+			md:        "## 概要\n**Go**の`context`を使う",
+			want:      "概要 Goのcontextを使う",
+			wantWords: 8,
+		},
+		{
+			name:      "HTMLエンティティ",
+			md:        "Tom &amp; Jerry",
+			want:      "Tom & Jerry",
+			wantWords: 2,
+		},
+		{
+			name: "空の本文",
+			md:   "",
+			want: "",
+		},
+		{
+			name: "コードブロックのみ",
+			md:   "```\ncode\n```",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, words := excerptFromMarkdown(tt.md)
+			if got != tt.want {
+				t.Errorf("excerptFromMarkdown() excerpt = %q, want %q", got, tt.want)
+			}
+			if words != tt.wantWords {
+				t.Errorf("excerptFromMarkdown() words = %d, want %d", words, tt.wantWords)
+			}
+		})
+	}
+}
+
+func TestExcerptFromHTML(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		want      string
+		wantWords int
+	}{
+		{
+			name:      "コードブロックを除く",
+			html:      "<p>Hello <b>world</b></p><pre><code>x := 1</code></pre><p>end</p>",
+			want:      "Hello world end",
+			wantWords: 3,
+		},
+		{
+			name:      "画像を含むfigureを除く",
+			html:      `<figure><img src="a.png"><figcaption>図1</figcaption></figure><p>本文</p>`,
+			want:      "本文",
+			wantWords: 2,
+		},
+		{
+			name:      "HTMLエンティティ",
+			html:      "<p>Tom &amp; Jerry &lt;3&gt;</p>",
+			want:      "Tom & Jerry <3>",
+			wantWords: 3,
+		},
+		{
+			name: "空の本文",
+			html: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, words := excerptFromHTML(tt.html)
+			if got != tt.want {
+				t.Errorf("excerptFromHTML() excerpt = %q, want %q", got, tt.want)
+			}
+			if words != tt.wantWords {
+				t.Errorf("excerptFromHTML() words = %d, want %d", words, tt.wantWords)
+			}
+		})
+	}
+}
+
+func TestExcerptTruncatesOnRuneBoundary(t *testing.T) {
+	text := strings.Repeat("あ", ExcerptLength+50)
+	got, words := excerptFromMarkdown(text)
+
+	if !utf8.ValidString(got) {
+		t.Fatalf("excerpt is not valid UTF-8: %q", got)
+	}
+	if want := strings.Repeat("あ", ExcerptLength) + "…"; got != want {
+		t.Errorf("excerpt = %q, want %d runes followed by an ellipsis", got, ExcerptLength)
+	}
+	// 語数は抜粋ではなく本文全体で数える
+	if words != ExcerptLength+50 {
+		t.Errorf("words = %d, want %d", words, ExcerptLength+50)
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{name: "英語", text: "hello, world 123", want: 3},
+		{name: "日本語は1文字を1語", text: "ひらがなカタカナ漢字", want: 10},
+		{name: "英語と日本語の混在", text: "Go言語で書く", want: 6},
+		{name: "記号で区切る", text: "C++とRust", want: 3},
+		{name: "空文字列", text: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countWords(tt.text); got != tt.want {
+				t.Errorf("countWords(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package fetcher
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// feedItem はRSS/Atomフィードの1エントリを共通の形に変換したものです。
+type feedItem struct {
+	Title       string
+	Link        string
+	GUID        string
+	Description string
+	Author      string
+	Categories  []string
+	PublishedAt time.Time
+	// BookmarkCount ははてなブックマークのフィードに含まれるブックマーク数です。
+	BookmarkCount int
+}
+
+// RSS 2.0 の構造体
+type rssFeed struct {
+	Channel struct {
+		Items []struct {
+			Title       string   `xml:"title"`
+			Link        string   `xml:"link"`
+			GUID        string   `xml:"guid"`
+			Description string   `xml:"description"`
+			PubDate     string   `xml:"pubDate"`
+			Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+			Author      string   `xml:"author"`
+			Categories  []string `xml:"category"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// Atom の構造体
+type atomFeed struct {
+	Entries []struct {
+		Title string `xml:"title"`
+		ID    string `xml:"id"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Summary    string `xml:"summary"`
+		Content    string `xml:"content"`
+		Published  string `xml:"published"`
+		Updated    string `xml:"updated"`
+		Categories []struct {
+			Term string `xml:"term,attr"`
+		} `xml:"category"`
+		Author struct {
+			Name string `xml:"name"`
+		} `xml:"author"`
+	} `xml:"entry"`
+}
+
+// RSS 1.0 (RDF) の構造体。はてなブックマークのフィードで使われる
+type rdfFeed struct {
+	Items []struct {
+		About         string   `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+		Title         string   `xml:"title"`
+		Link          string   `xml:"link"`
+		Description   string   `xml:"description"`
+		Date          string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+		Creator       string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+		Subjects      []string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+		BookmarkCount int      `xml:"http://www.hatena.ne.jp/info/xmlns# bookmarkcount"`
+	} `xml:"item"`
+}
+
+// parseFeed はRSS 1.0/2.0またはAtomのフィードを読み込み、エントリの一覧を返します。
+func parseFeed(r io.Reader) ([]feedItem, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed body: %w", err)
+	}
+
+	// ルート要素の名前でフィードの形式を判定
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(body, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse feed: %w", err)
+	}
+
+	switch root.XMLName.Local {
+	case "rss":
+		var feed rssFeed
+		if err := xml.Unmarshal(body, &feed); err != nil {
+			return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+		}
+		items := make([]feedItem, 0, len(feed.Channel.Items))
+		for _, it := range feed.Channel.Items {
+			author := it.Creator
+			if author == "" {
+				author = it.Author
+			}
+			items = append(items, feedItem{
+				Title:       strings.TrimSpace(it.Title),
+				Link:        strings.TrimSpace(it.Link),
+				GUID:        strings.TrimSpace(it.GUID),
+				Description: it.Description,
+				Author:      strings.TrimSpace(author),
+				Categories:  trimAll(it.Categories),
+				PublishedAt: parseFeedTime(it.PubDate),
+			})
+		}
+		return items, nil
+
+	case "feed":
+		var feed atomFeed
+		if err := xml.Unmarshal(body, &feed); err != nil {
+			return nil, fmt.Errorf("failed to parse Atom feed: %w", err)
+		}
+		items := make([]feedItem, 0, len(feed.Entries))
+		for _, e := range feed.Entries {
+			// rel="alternate"（または rel 指定なし）のリンクを記事URLとして扱う
+			var link string
+			for _, l := range e.Links {
+				if l.Rel == "" || l.Rel == "alternate" {
+					link = l.Href
+					break
+				}
+			}
+			published := e.Published
+			if published == "" {
+				published = e.Updated
+			}
+			description := e.Summary
+			if description == "" {
+				description = e.Content
+			}
+			var categories []string
+			for _, c := range e.Categories {
+				categories = append(categories, c.Term)
+			}
+			items = append(items, feedItem{
+				Title:       strings.TrimSpace(e.Title),
+				Link:        strings.TrimSpace(link),
+				GUID:        strings.TrimSpace(e.ID),
+				Description: description,
+				Author:      strings.TrimSpace(e.Author.Name),
+				Categories:  trimAll(categories),
+				PublishedAt: parseFeedTime(published),
+			})
+		}
+		return items, nil
+
+	case "RDF":
+		var feed rdfFeed
+		if err := xml.Unmarshal(body, &feed); err != nil {
+			return nil, fmt.Errorf("failed to parse RSS 1.0 feed: %w", err)
+		}
+		items := make([]feedItem, 0, len(feed.Items))
+		for _, it := range feed.Items {
+			link := it.Link
+			if link == "" {
+				link = it.About
+			}
+			items = append(items, feedItem{
+				Title:         strings.TrimSpace(it.Title),
+				Link:          strings.TrimSpace(link),
+				GUID:          strings.TrimSpace(it.About),
+				Description:   it.Description,
+				Author:        strings.TrimSpace(it.Creator),
+				Categories:    trimAll(it.Subjects),
+				PublishedAt:   parseFeedTime(it.Date),
+				BookmarkCount: it.BookmarkCount,
+			})
+		}
+		return items, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported feed format: <%s>", root.XMLName.Local)
+	}
+}
+
+// フィードで使われる日付形式の候補
+var feedTimeLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02",
+}
+
+// parseFeedTime はフィード内の日付文字列をパースします。パースできない場合はゼロ値を返します。
+func parseFeedTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// 空要素を除き、前後の空白を取り除く
+func trimAll(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
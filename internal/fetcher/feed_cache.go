@@ -0,0 +1,101 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 同じフィードをタグごとに何度も取得しないよう、パース結果を保持する時間
+const feedCacheTTL = 5 * time.Minute
+
+// feedCache はフィードのパース結果をURLごとに保持するキャッシュです。
+// 同じURLを同時に要求された場合は1回だけ取得し、待っていた呼び出し元は同じ結果を使います。
+type feedCache struct {
+	http requester
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*feedCacheEntry
+}
+
+// feedCacheEntry は1つのフィードのキャッシュです。lockを持つ呼び出し元だけが取得・更新します。
+type feedCacheEntry struct {
+	lock        chan struct{}
+	items       []feedItem
+	notModified bool       // 直近の取得が304だった（itemsはそれ以前に取得したもの）
+	validators  Validators // itemsを取得したレスポンスの検証子（304の場合は保存済みのため空）
+	fetchedAt   time.Time
+}
+
+// cachedFeed はフィードの取得結果です。
+// 検証子は取得結果を使ったFetchが成功した場合のみ保存するよう、呼び出し元で記録する
+type cachedFeed struct {
+	items       []feedItem
+	notModified bool // 前回の実行から変更がなかった
+	validators  Validators
+}
+
+func newFeedCache(r requester) *feedCache {
+	return &feedCache{http: r, ttl: feedCacheTTL, entries: make(map[string]*feedCacheEntry)}
+}
+
+func (c *feedCache) entry(feedURL string) *feedCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[feedURL]
+	if !ok {
+		e = &feedCacheEntry{lock: make(chan struct{}, 1)}
+		c.entries[feedURL] = e
+	}
+	return e
+}
+
+// get はフィードを取得してパースします。一定時間内の再取得にはキャッシュを使います。
+// 304の場合もそれ以前に取得した記事は残し、取得に失敗した場合はキャッシュを更新しません。
+func (c *feedCache) get(ctx context.Context, feedURL string) (cachedFeed, error) {
+	e := c.entry(feedURL)
+	select {
+	case e.lock <- struct{}{}:
+	case <-ctx.Done():
+		return cachedFeed{}, ctx.Err()
+	}
+	defer func() { <-e.lock }()
+
+	if !e.fetchedAt.IsZero() && time.Since(e.fetchedAt) < c.ttl {
+		return cachedFeed{items: e.items, notModified: e.notModified, validators: e.validators}, nil
+	}
+
+	res, err := c.http.get(ctx, feedURL, nil)
+	if err != nil {
+		return cachedFeed{}, fmt.Errorf("failed to fetch feed %s: %w", feedURL, err)
+	}
+	defer res.Body.Close()
+
+	// 前回の取得から変更がない
+	if res.StatusCode == http.StatusNotModified {
+		e.notModified = true
+		e.validators = Validators{}
+		e.fetchedAt = time.Now()
+		return cachedFeed{items: e.items, notModified: true}, nil
+	}
+
+	// ステータスコードを確認
+	if res.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return cachedFeed{}, fmt.Errorf("feed %s returned non-200 status: %d, body: %s", feedURL, res.StatusCode, bodyBytes)
+	}
+
+	items, err := parseFeed(res.Body)
+	if err != nil {
+		return cachedFeed{}, fmt.Errorf("failed to parse feed %s: %w", feedURL, err)
+	}
+	e.items = items
+	e.notModified = false
+	e.validators = Validators{ETag: res.Header.Get("ETag"), LastModified: res.Header.Get("Last-Modified")}
+	e.fetchedAt = time.Now()
+	return cachedFeed{items: items, validators: e.validators}, nil
+}
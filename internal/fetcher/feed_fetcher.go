@@ -0,0 +1,140 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// FeedConfig は汎用フィードソース（RSS 2.0 / Atom）の設定です。
+type FeedConfig struct {
+	// Name はソース名です。FETCH_SOURCESでの指定に使われ、ソース間で一意である必要があります。
+	Name string
+	// URL はフィードのURLです。
+	URL string
+	// Source は記事のSourceに設定するラベルです（例: "Hatena Blog"）。空の場合はNameを使用します。
+	Source string
+	// DefaultTags はフィードのすべての記事に付与するタグです。
+	DefaultTags []string
+	// HTTP はHTTPクライアントの設定です。
+	HTTP HTTPConfig
+}
+
+// FeedFetcher は任意のRSS 2.0 / Atomフィードから記事を取得するFetcherです。
+// フィードはタグごとに取得せず、同時に実行されたタグの間で1回の取得結果を共有します。
+type FeedFetcher struct {
+	cfg   FeedConfig
+	cache *feedCache
+}
+
+// NewFeedFetcher はFeedFetcherの新しいインスタンスを作成します。
+func NewFeedFetcher(cfg FeedConfig) *FeedFetcher {
+	if cfg.Source == "" {
+		cfg.Source = cfg.Name
+	}
+	return &FeedFetcher{cfg: cfg, cache: newFeedCache(newRequester(cfg.HTTP))}
+}
+
+// Name はソース名を返します。
+func (f *FeedFetcher) Name() string {
+	return f.cfg.Name
+}
+
+// Commit は記事の保存に成功した後に呼び出し、取得したレスポンスの検証子を保存します。
+func (f *FeedFetcher) Commit(ctx context.Context) error {
+	return f.cache.http.commitValidators(ctx)
+}
+
+// Fetch はフィードを取得し、指定したタグを持つ記事を返します。
+// 記事のタグはDefaultTagsとフィード内のカテゴリです。tagが空の場合はすべての記事を返します。
+func (f *FeedFetcher) Fetch(ctx context.Context, tag string) ([]model.Article, error) {
+	feed, err := f.cache.get(ctx, f.cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	// 記事を返すため、取得結果の検証子を保存の対象にする
+	batch := validatorBatch{}
+	batch.add(f.cfg.URL, feed.validators)
+	f.cache.http.keepValidators(batch, nil)
+
+	// 前回の実行から変更がない
+	if feed.notModified {
+		return nil, ErrNotModified
+	}
+	items := feed.items
+
+	articles := make([]model.Article, 0, len(items))
+	for _, it := range items {
+		if it.Link == "" {
+			continue
+		}
+		tags := mergeTags(f.cfg.DefaultTags, it.Categories)
+		if tag != "" {
+			if !containsFold(tags, tag) {
+				continue
+			}
+			// 検索は完全一致のため、取得に使ったタグの表記に揃えて付与する
+			tags = mergeTags([]string{tag}, tags)
+		}
+
+		// フィードの説明は本文の一部のことが多いため、抜粋にのみ使い語数は設定しない
+		excerpt, _ := excerptFromHTML(it.Description)
+
+		articles = append(articles, model.Article{
+			ID:          feedArticleID(f.cfg.Name, it),
+			Title:       it.Title,
+			URL:         it.Link,
+			Tags:        tags,
+			PublishedAt: it.PublishedAt,
+			Source:      f.cfg.Source,
+			Excerpt:     excerpt,
+			Author:      feedAuthor(it),
+		})
+	}
+	return articles, nil
+}
+
+// フィードの記事からドキュメントIDを生成する
+// URLはFirestoreのドキュメントIDに使えない文字を含むため、GUID（なければURL）のハッシュを使う
+func feedArticleID(name string, it feedItem) string {
+	key := it.GUID
+	if key == "" {
+		key = it.Link
+	}
+	sum := sha1.Sum([]byte(key))
+	return name + "-" + hex.EncodeToString(sum[:10])
+}
+
+// タグのリストを結合する。大文字小文字のみ異なるタグは先に現れたものを残す
+func mergeTags(lists ...[]string) []string {
+	var tags []string
+	for _, list := range lists {
+		for _, t := range list {
+			if t = strings.TrimSpace(t); t != "" && !containsFold(tags, t) {
+				tags = append(tags, t)
+			}
+		}
+	}
+	return tags
+}
+
+// 大文字小文字を区別せずにvalueが含まれているかを判定する
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// フィードの著者名から著者を作成する。フィードにはユーザーIDがないため、著者名をIDとして扱う
+func feedAuthor(it feedItem) *model.Author {
+	if it.Author == "" {
+		return nil
+	}
+	return &model.Author{ID: it.Author, Name: it.Author}
+}
@@ -0,0 +1,82 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestFeedFetcherFetchesOncePerRun(t *testing.T) {
+	body, err := os.ReadFile("testdata/zenn_topic_go.atom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	f := NewFeedFetcher(FeedConfig{
+		Name:        "example",
+		URL:         srv.URL + "/feed.atom",
+		Source:      "Example Blog",
+		DefaultTags: []string{"Programming"},
+		HTTP:        HTTPConfig{Validators: NewMemoryValidatorStore()},
+	})
+
+	// 2つ目以降のタグも、1つ目のタグで取得したフィードから絞り込む
+	for _, tt := range []struct {
+		tag  string
+		want []string
+	}{
+		{tag: "go", want: []string{"go", "Programming", "Generics"}},
+		{tag: "Generics", want: []string{"Generics", "Programming", "Go"}},
+		{tag: "", want: []string{"Programming", "Go", "Generics"}},
+		{tag: "Rust"},
+	} {
+		articles, err := f.Fetch(context.Background(), tt.tag)
+		if err != nil {
+			t.Fatalf("Fetch(%q) error = %v", tt.tag, err)
+		}
+		if tt.want == nil {
+			if len(articles) != 0 {
+				t.Errorf("Fetch(%q) returned %d articles, want 0", tt.tag, len(articles))
+			}
+			continue
+		}
+		if len(articles) != 1 {
+			t.Fatalf("Fetch(%q) returned %d articles, want 1", tt.tag, len(articles))
+		}
+		a := articles[0]
+		if !slices.Equal(a.Tags, tt.want) {
+			t.Errorf("Fetch(%q) Tags = %v, want %v", tt.tag, a.Tags, tt.want)
+		}
+		if a.Source != "Example Blog" || a.URL != "https://zenn.dev/gopher/articles/go-generics-intro" {
+			t.Errorf("Fetch(%q) = {%q %q}", tt.tag, a.Source, a.URL)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("server received %d requests, want 1", requests)
+	}
+
+	// 保存を確定した後、キャッシュの期限が切れてからの304は、すべてのタグで変更なしとなる
+	if err := f.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	f.cache.ttl = 0
+	for _, tag := range []string{"Go", "Generics"} {
+		if _, err := f.Fetch(context.Background(), tag); err != ErrNotModified {
+			t.Errorf("Fetch(%q) error = %v, want ErrNotModified", tag, err)
+		}
+	}
+}
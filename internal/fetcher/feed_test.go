@@ -0,0 +1,131 @@
+package fetcher
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFeed(t *testing.T) {
+	jst := time.FixedZone("", 9*60*60)
+
+	tests := []struct {
+		name    string
+		fixture string
+		want    []feedItem
+	}{
+		{
+			name:    "RSS 2.0",
+			fixture: "testdata/zenn_topic_go.xml",
+			want: []feedItem{
+				{
+					Title:       "Goのcontextを正しく使う",
+					Link:        "https://zenn.dev/gopher/articles/go-context-guide",
+					GUID:        "https://zenn.dev/gopher/articles/go-context-guide",
+					Author:      "Gopher Taro",
+					PublishedAt: time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC),
+				},
+				{
+					Title:       "Echoで作るREST API",
+					Link:        "https://zenn.dev/echo_user/articles/echo-rest-api",
+					GUID:        "https://zenn.dev/echo_user/articles/echo-rest-api",
+					Author:      "echo_user",
+					PublishedAt: time.Date(2024, 6, 30, 9, 30, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:    "Atom",
+			fixture: "testdata/zenn_topic_go.atom",
+			want: []feedItem{
+				{
+					Title:       "Goのジェネリクス入門",
+					Link:        "https://zenn.dev/gopher/articles/go-generics-intro",
+					GUID:        "https://zenn.dev/gopher/articles/go-generics-intro",
+					Author:      "Gopher Taro",
+					Categories:  []string{"Go", "Generics"},
+					PublishedAt: time.Date(2024, 7, 1, 9, 0, 0, 0, jst),
+				},
+			},
+		},
+		{
+			name:    "RSS 1.0 (RDF)",
+			fixture: "testdata/hatena_hotentry_it.rdf",
+			want: []feedItem{
+				{
+					Title:         "Go 1.23 の新機能まとめ",
+					Link:          "https://example.com/blog/go-1-23",
+					GUID:          "https://example.com/blog/go-1-23",
+					Author:        "hatena_user",
+					Categories:    []string{"テクノロジー"},
+					PublishedAt:   time.Date(2024, 7, 1, 8, 0, 0, 0, jst),
+					BookmarkCount: 321,
+				},
+				{
+					Title:         "Google Cloud Run を使ってみた",
+					Link:          "https://example.com/blog/google-cloud-run",
+					GUID:          "https://example.com/blog/google-cloud-run",
+					PublishedAt:   time.Date(2024, 6, 30, 20, 0, 0, 0, jst),
+					BookmarkCount: 45,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			items, err := parseFeed(f)
+			if err != nil {
+				t.Fatalf("parseFeed() error = %v", err)
+			}
+			if len(items) != len(tt.want) {
+				t.Fatalf("parseFeed() returned %d items, want %d", len(items), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := items[i]
+				if got.Title != want.Title || got.Link != want.Link || got.GUID != want.GUID || got.Author != want.Author {
+					t.Errorf("item %d = {%q %q %q %q}, want {%q %q %q %q}", i,
+						got.Title, got.Link, got.GUID, got.Author, want.Title, want.Link, want.GUID, want.Author)
+				}
+				if !slices.Equal(got.Categories, want.Categories) {
+					t.Errorf("item %d categories = %v, want %v", i, got.Categories, want.Categories)
+				}
+				if !got.PublishedAt.Equal(want.PublishedAt) {
+					t.Errorf("item %d PublishedAt = %v, want %v", i, got.PublishedAt, want.PublishedAt)
+				}
+				if got.BookmarkCount != want.BookmarkCount {
+					t.Errorf("item %d BookmarkCount = %d, want %d", i, got.BookmarkCount, want.BookmarkCount)
+				}
+				if got.Description == "" {
+					t.Errorf("item %d has empty description", i)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFeedUnsupported(t *testing.T) {
+	if _, err := parseFeed(strings.NewReader(`<html><body>not a feed</body></html>`)); err == nil {
+		t.Fatal("parseFeed() error = nil, want error for unsupported format")
+	}
+}
+
+func TestNewDefaultRegistryDuplicateFeed(t *testing.T) {
+	r := NewDefaultRegistry(Config{Feeds: []FeedConfig{
+		{Name: "qiita", URL: "https://example.com/feed.xml"},
+		{Name: "mercari", URL: "https://engineering.mercari.com/blog/feed.xml"},
+		{Name: "mercari", URL: "https://example.com/feed.xml"},
+	}})
+	want := []string{"qiita", "zenn", "hatena", "devto", "hackernews", "mercari"}
+	if got := r.Names(); !slices.Equal(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// Fetcher は記事の取得元（ソース）を抽象化するインターフェースです。
+type Fetcher interface {
+	// Name はソースを識別する名前（例: "qiita"）を返します。設定での指定に使われます。
+	Name() string
+	// Fetch は指定したタグの記事を取得します。
+	Fetch(ctx context.Context, tag string) ([]model.Article, error)
+}
+
+// Committer は取得した記事の保存に成功した後に、取得の進捗を確定するFetcherです。
+// 保存前に進捗を記録すると、保存に失敗した記事が次回以降の取得の対象から外れてしまうためです。
+type Committer interface {
+	// Commit は前回のCommit以降に取得した結果の検証子（ETag/Last-Modified）などを保存します。
+	Commit(ctx context.Context) error
+}
+
+// Registry は利用可能なFetcherを名前で管理するレジストリです。
+type Registry struct {
+	fetchers map[string]Fetcher
+	order    []string // 登録順
+}
+
+// NewRegistry は空のRegistryを作成します。
+func NewRegistry() *Registry {
+	return &Registry{fetchers: make(map[string]Fetcher)}
+}
+
+// Config は組み込みソースの設定です。
+type Config struct {
+	// HTTP は各ソース共通のHTTPクライアントの設定です。ソースごとの設定が優先されます。
+	HTTP       HTTPConfig
+	Qiita      QiitaConfig
+	Zenn       ZennConfig
+	Hatena     HatenaConfig
+	DevTo      DevToConfig
+	HackerNews HackerNewsConfig
+	// Feeds は汎用フィードソースの設定です。それぞれ設定したNameで登録されます。
+	Feeds []FeedConfig
+}
+
+// NewDefaultRegistry は組み込みのソースを登録済みのRegistryを作成します。
+func NewDefaultRegistry(cfg Config) *Registry {
+	cfg.Qiita.HTTP = cfg.Qiita.HTTP.orDefault(cfg.HTTP)
+	cfg.Zenn.HTTP = cfg.Zenn.HTTP.orDefault(cfg.HTTP)
+	cfg.Hatena.HTTP = cfg.Hatena.HTTP.orDefault(cfg.HTTP)
+	cfg.DevTo.HTTP = cfg.DevTo.HTTP.orDefault(cfg.HTTP)
+	cfg.HackerNews.HTTP = cfg.HackerNews.HTTP.orDefault(cfg.HTTP)
+
+	r := NewRegistry()
+	r.MustRegister(NewQiitaFetcher(cfg.Qiita))
+	r.MustRegister(NewZennFetcher(cfg.Zenn))
+	r.MustRegister(NewHatenaFetcher(cfg.Hatena))
+	r.MustRegister(NewDevToFetcher(cfg.DevTo))
+	r.MustRegister(NewHackerNewsFetcher(cfg.HackerNews))
+	for _, feed := range cfg.Feeds {
+		feed.HTTP = feed.HTTP.orDefault(cfg.HTTP)
+		// 設定の誤りで起動できなくならないよう、名前が重複するフィードや
+		// 記事のドキュメントIDに使えない"/"を含む名前のフィードはスキップする
+		if strings.Contains(feed.Name, "/") {
+			log.Printf("skipping feed source: name %q must not contain \"/\"", feed.Name)
+			continue
+		}
+		if err := r.Register(NewFeedFetcher(feed)); err != nil {
+			log.Printf("skipping feed source: %v", err)
+		}
+	}
+	return r
+}
+
+// Register はFetcherを登録します。同じ名前のFetcherが既に登録されている場合はエラーを返します。
+func (r *Registry) Register(f Fetcher) error {
+	name := strings.ToLower(f.Name())
+	if _, ok := r.fetchers[name]; ok {
+		return fmt.Errorf("fetcher %q is already registered", name)
+	}
+	r.fetchers[name] = f
+	r.order = append(r.order, name)
+	return nil
+}
+
+// MustRegister はRegisterと同じですが、失敗した場合はpanicします。
+func (r *Registry) MustRegister(f Fetcher) {
+	if err := r.Register(f); err != nil {
+		panic(err)
+	}
+}
+
+// Names は登録済みのソース名を登録順に返します。
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.order))
+	copy(names, r.order)
+	return names
+}
+
+// Select は指定した名前のFetcherを指定順に返します。
+// namesが空の場合は登録済みのすべてのFetcherを登録順に返します。
+// 未登録の名前が含まれている場合はエラーを返します。
+func (r *Registry) Select(names []string) ([]Fetcher, error) {
+	if len(names) == 0 {
+		names = r.order
+	}
+	fetchers := make([]Fetcher, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		f, ok := r.fetchers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown fetcher %q (available: %s)", name, strings.Join(r.order, ", "))
+		}
+		seen[name] = true
+		fetchers = append(fetchers, f)
+	}
+	return fetchers, nil
+}
@@ -0,0 +1,196 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/langdetect"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+const (
+	// DefaultHackerNewsBaseURL はHacker News Search API (Algolia) のベースURLです。
+	DefaultHackerNewsBaseURL = "https://hn.algolia.com/api/v1"
+	// DefaultHackerNewsMaxArticlesPerTag はタグごとに取得する記事数のデフォルト値です。
+	DefaultHackerNewsMaxArticlesPerTag = 30
+	// DefaultHackerNewsTopDays は何日前までのストーリーを対象とするかのデフォルト値です。
+	DefaultHackerNewsTopDays = 30
+	// DefaultHackerNewsMinPoints は取得対象とするストーリーの最低ポイントのデフォルト値です。
+	DefaultHackerNewsMinPoints = 50
+
+	hackerNewsItemURL = "https://news.ycombinator.com/item?id="
+)
+
+// Hacker News Search API レスポンスの構造体を定義
+type hackerNewsSearchResponse struct {
+	Hits []struct {
+		ObjectID  string `json:"objectID"`
+		Title     string `json:"title"`
+		URL       string `json:"url"` // Ask HNなどでは空
+		Points    int    `json:"points"`
+		Author    string `json:"author"`
+		StoryText string `json:"story_text"` // Ask HNなどの本文（HTML）
+		CreatedAt string `json:"created_at"` // ISO 8601 format
+	} `json:"hits"`
+}
+
+// HackerNewsConfig はHackerNewsFetcherの設定です。
+type HackerNewsConfig struct {
+	// MaxArticlesPerTag はタグごとに取得する最大記事数です。0以下の場合はデフォルト値を使用します。
+	MaxArticlesPerTag int
+	// TopDays は直近何日間のストーリーを対象とするかです。0以下の場合はデフォルト値を使用します。
+	TopDays int
+	// MinPoints は取得対象とするストーリーの最低ポイントです。0以下の場合はデフォルト値を使用します。
+	MinPoints int
+	// BaseURL はAPIのベースURLです。空の場合はDefaultHackerNewsBaseURLを使用します。
+	BaseURL string
+	// HTTP はHTTPクライアントの設定です。
+	HTTP HTTPConfig
+}
+
+// HackerNewsFetcher はHacker News Search API (Algolia) からキーワードに一致する人気ストーリーを取得するFetcherです。
+// ポイントを記事のいいね数として扱います。
+type HackerNewsFetcher struct {
+	maxPerTag int
+	topDays   int
+	minPoints int
+	baseURL   string
+	http      requester
+}
+
+// NewHackerNewsFetcher はHackerNewsFetcherの新しいインスタンスを作成します。
+func NewHackerNewsFetcher(cfg HackerNewsConfig) *HackerNewsFetcher {
+	f := &HackerNewsFetcher{
+		maxPerTag: cfg.MaxArticlesPerTag,
+		topDays:   cfg.TopDays,
+		minPoints: cfg.MinPoints,
+		baseURL:   strings.TrimSuffix(cfg.BaseURL, "/"),
+		http:      newRequester(cfg.HTTP),
+	}
+	if f.maxPerTag <= 0 {
+		f.maxPerTag = DefaultHackerNewsMaxArticlesPerTag
+	}
+	if f.topDays <= 0 {
+		f.topDays = DefaultHackerNewsTopDays
+	}
+	if f.minPoints <= 0 {
+		f.minPoints = DefaultHackerNewsMinPoints
+	}
+	if f.baseURL == "" {
+		f.baseURL = DefaultHackerNewsBaseURL
+	}
+	return f
+}
+
+// Name はソース名を返します。
+func (f *HackerNewsFetcher) Name() string {
+	return "hackernews"
+}
+
+// Commit は記事の保存に成功した後に呼び出し、取得したレスポンスの検証子を保存します。
+func (f *HackerNewsFetcher) Commit(ctx context.Context) error {
+	return f.http.commitValidators(ctx)
+}
+
+// Fetch はタグをキーワードとして直近の人気ストーリーを検索します。
+// ストーリーにはタグがないため、検索に使ったタグを付与します。
+func (f *HackerNewsFetcher) Fetch(ctx context.Context, tag string) ([]model.Article, error) {
+	// 条件付きリクエストが効くよう、期間の起点は日単位に丸めてURLを安定させる
+	since := time.Now().Truncate(24*time.Hour).AddDate(0, 0, -f.topDays).Unix()
+
+	// クエリパラメータを設定
+	params := url.Values{}
+	params.Add("query", tag)
+	params.Add("tags", "story")
+	params.Add("hitsPerPage", strconv.Itoa(f.maxPerTag))
+	params.Add("numericFilters", fmt.Sprintf("points>=%d,created_at_i>%d", f.minPoints, since))
+	reqURL := f.baseURL + "/search?" + params.Encode()
+
+	res, err := f.http.get(ctx, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Hacker News stories: %w", err)
+	}
+	defer res.Body.Close()
+
+	// 前回の取得から変更がない
+	if res.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+
+	// ステータスコードを確認
+	if res.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("Hacker News API returned non-200 status: %d, body: %s", res.StatusCode, bodyBytes)
+	}
+
+	// JSONをパース
+	var searchRes hackerNewsSearchResponse
+	if err := json.NewDecoder(res.Body).Decode(&searchRes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Hacker News API response: %w", err)
+	}
+	f.http.saveValidators(reqURL, res.Header)
+
+	return hackerNewsArticles(searchRes, tag), nil
+}
+
+// 検索結果を内部モデルにマッピング
+func hackerNewsArticles(searchRes hackerNewsSearchResponse, tag string) []model.Article {
+	var tags []string
+	if tag != "" {
+		tags = []string{tag}
+	}
+
+	articles := make([]model.Article, 0, len(searchRes.Hits))
+	for _, hit := range searchRes.Hits {
+		if hit.ObjectID == "" || hit.Title == "" {
+			continue
+		}
+
+		// Ask HNなど外部URLを持たないストーリーはHNのページを記事URLとする
+		articleURL := hit.URL
+		if articleURL == "" {
+			articleURL = hackerNewsItemURL + hit.ObjectID
+		}
+
+		var publishedAt time.Time
+		if t, err := time.Parse(time.RFC3339, hit.CreatedAt); err == nil {
+			publishedAt = t
+		}
+
+		excerpt, wordCount := excerptFromHTML(hit.StoryText)
+
+		articles = append(articles, model.Article{
+			ID:          "hn-" + hit.ObjectID,
+			Title:       hit.Title,
+			URL:         articleURL,
+			Tags:        tags,
+			Likes:       hit.Points,
+			PublishedAt: publishedAt,
+			Source:      "Hacker News",
+			Lang:        langdetect.English,
+			Excerpt:     excerpt,
+			WordCount:   wordCount,
+			Author:      hackerNewsAuthor(hit.Author),
+		})
+	}
+	return articles
+}
+
+// HNのユーザー名から著者を作成する
+func hackerNewsAuthor(username string) *model.Author {
+	if username == "" {
+		return nil
+	}
+	return &model.Author{
+		ID:         username,
+		Name:       username,
+		ProfileURL: "https://news.ycombinator.com/user?id=" + url.QueryEscape(username),
+	}
+}
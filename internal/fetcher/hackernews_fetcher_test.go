@@ -0,0 +1,87 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHackerNewsFetcherFetch(t *testing.T) {
+	body, err := os.ReadFile("testdata/hackernews_search_go.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var header http.Header
+	var params map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" {
+			http.NotFound(w, r)
+			return
+		}
+		header = r.Header
+		params = map[string]string{}
+		for k := range r.URL.Query() {
+			params[k] = r.URL.Query().Get(k)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	f := NewHackerNewsFetcher(HackerNewsConfig{BaseURL: srv.URL, MinPoints: 80, TopDays: 7})
+	articles, err := f.Fetch(context.Background(), "Go")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	// 検索条件
+	if params["query"] != "Go" || params["tags"] != "story" || params["hitsPerPage"] != "30" {
+		t.Errorf("query params = %v", params)
+	}
+	since := time.Now().Truncate(24*time.Hour).AddDate(0, 0, -7).Unix()
+	if want := fmt.Sprintf("points>=80,created_at_i>%d", since); params["numericFilters"] != want {
+		t.Errorf("numericFilters = %q, want %q", params["numericFilters"], want)
+	}
+	if header.Get("User-Agent") != DefaultUserAgent {
+		t.Errorf("User-Agent = %q, want %q", header.Get("User-Agent"), DefaultUserAgent)
+	}
+
+	// タイトルのないストーリーは除外される
+	if len(articles) != 2 {
+		t.Fatalf("Fetch() returned %d articles, want 2", len(articles))
+	}
+
+	story := articles[0]
+	if story.ID != "hn-40850001" || story.URL != "https://go.dev/doc/go1.23" || story.Likes != 412 {
+		t.Errorf("story = {%q %q %d}, want {hn-40850001 https://go.dev/doc/go1.23 412}", story.ID, story.URL, story.Likes)
+	}
+	if len(story.Tags) != 1 || story.Tags[0] != "Go" {
+		t.Errorf("story Tags = %v, want [Go]", story.Tags)
+	}
+	if !story.PublishedAt.Equal(time.Date(2024, 7, 1, 12, 34, 56, 0, time.UTC)) {
+		t.Errorf("story PublishedAt = %v", story.PublishedAt)
+	}
+	if story.Source != "Hacker News" || story.Lang != "en" {
+		t.Errorf("story Source/Lang = %q/%q", story.Source, story.Lang)
+	}
+	if story.Author == nil || story.Author.ProfileURL != "https://news.ycombinator.com/user?id=gopher" {
+		t.Errorf("story Author = %+v", story.Author)
+	}
+
+	// Ask HNは外部URLがないため、HNのページを記事URLとする
+	ask := articles[1]
+	if ask.URL != "https://news.ycombinator.com/item?id=40840002" {
+		t.Errorf("Ask HN URL = %q, want https://news.ycombinator.com/item?id=40840002", ask.URL)
+	}
+	if ask.Likes != 87 {
+		t.Errorf("Ask HN Likes = %d, want 87", ask.Likes)
+	}
+	if !strings.HasPrefix(ask.Excerpt, "I'm starting a new service in Go") {
+		t.Errorf("Ask HN Excerpt = %q", ask.Excerpt)
+	}
+}
@@ -0,0 +1,138 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// DefaultHatenaBaseURL ははてなブックマークのベースURLです。
+const DefaultHatenaBaseURL = "https://b.hatena.ne.jp"
+
+// DefaultHatenaFeeds は取得するテクノロジーカテゴリのフィード（人気エントリー・新着エントリー）です。
+var DefaultHatenaFeeds = []string{"/hotentry/it.rss", "/entrylist/it.rss"}
+
+// HatenaConfig はHatenaFetcherの設定です。
+type HatenaConfig struct {
+	// BaseURL ははてなブックマークのベースURLです。空の場合はDefaultHatenaBaseURLを使用します。
+	BaseURL string
+	// Feeds はBaseURLからのフィードのパスです。空の場合はDefaultHatenaFeedsを使用します。
+	Feeds []string
+	// HTTP はHTTPクライアントの設定です。
+	HTTP HTTPConfig
+}
+
+// HatenaFetcher ははてなブックマークのテクノロジーカテゴリのエントリーを取得するFetcherです。
+// ブックマーク数を記事のいいね数として扱います。
+// フィードはタグごとに取得せず、同時に実行されたタグの間で1回の取得結果を共有します。
+type HatenaFetcher struct {
+	baseURL string
+	feeds   []string
+	cache   *feedCache
+}
+
+// NewHatenaFetcher はHatenaFetcherの新しいインスタンスを作成します。
+func NewHatenaFetcher(cfg HatenaConfig) *HatenaFetcher {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultHatenaBaseURL
+	}
+	feeds := cfg.Feeds
+	if len(feeds) == 0 {
+		feeds = DefaultHatenaFeeds
+	}
+	return &HatenaFetcher{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		feeds:   feeds,
+		cache:   newFeedCache(newRequester(cfg.HTTP)),
+	}
+}
+
+// Name はソース名を返します。
+func (f *HatenaFetcher) Name() string {
+	return "hatena"
+}
+
+// Commit は記事の保存に成功した後に呼び出し、取得したレスポンスの検証子を保存します。
+func (f *HatenaFetcher) Commit(ctx context.Context) error {
+	return f.cache.http.commitValidators(ctx)
+}
+
+// Fetch はテクノロジーカテゴリのエントリーのうち、タイトルまたは説明にタグを含むものを返します。
+// エントリーにはタグ情報がないため、取得に使ったタグを付与します。tagが空の場合はすべてのエントリーを返します。
+func (f *HatenaFetcher) Fetch(ctx context.Context, tag string) ([]model.Article, error) {
+	// 途中のフィードで失敗した場合は、取得済みのフィードの検証子も保存しない
+	batch := validatorBatch{}
+	articles, err := f.fetch(ctx, tag, batch)
+	f.cache.http.keepValidators(batch, err)
+	return articles, err
+}
+
+func (f *HatenaFetcher) fetch(ctx context.Context, tag string, batch validatorBatch) ([]model.Article, error) {
+	matcher := tagMatcher(tag)
+
+	var articles []model.Article
+	seen := make(map[string]bool)
+	unchanged := 0
+	for _, path := range f.feeds {
+		feed, err := f.cache.get(ctx, f.baseURL+path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Hatena Bookmark feed: %w", err)
+		}
+		batch.add(f.baseURL+path, feed.validators)
+		if feed.notModified {
+			unchanged++
+			continue
+		}
+		for _, it := range feed.items {
+			if it.Link == "" || seen[it.Link] {
+				continue
+			}
+			if matcher != nil && !matcher.MatchString(it.Title+"\n"+it.Description) {
+				continue
+			}
+			seen[it.Link] = true
+
+			var tags []string
+			if tag != "" {
+				tags = []string{tag}
+			}
+			sum := sha1.Sum([]byte(it.Link))
+
+			// エントリーの説明は記事の要約のため、抜粋にのみ使い語数は設定しない
+			excerpt, _ := excerptFromHTML(it.Description)
+
+			articles = append(articles, model.Article{
+				ID:          "hatena-" + hex.EncodeToString(sum[:10]),
+				Title:       it.Title,
+				URL:         it.Link,
+				Tags:        tags,
+				Likes:       it.BookmarkCount, // ブックマーク数をいいね数として扱う
+				PublishedAt: it.PublishedAt,
+				Source:      "Hatena Bookmark",
+				Excerpt:     excerpt,
+				Author:      feedAuthor(it),
+			})
+		}
+	}
+
+	// すべてのフィードに変更がなければ、変更なしとして扱う
+	if unchanged == len(f.feeds) {
+		return nil, ErrNotModified
+	}
+	return articles, nil
+}
+
+// タグがテキストに含まれるかを判定する正規表現を作成する
+// 前後が英数字の場合は一致としない（"Go" が "Google" に一致せず、"Go言語" には一致するように）
+func tagMatcher(tag string) *regexp.Regexp {
+	if tag == "" {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)(^|[^a-z0-9])` + regexp.QuoteMeta(tag) + `($|[^a-z0-9])`)
+}
@@ -0,0 +1,179 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func newHatenaTestServer(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile("testdata/hatena_hotentry_it.rdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHatenaFetcherSharesFeedAcrossTags(t *testing.T) {
+	var requests atomic.Int32
+	srv := newHatenaTestServer(t, &requests)
+	f := NewHatenaFetcher(HatenaConfig{
+		BaseURL: srv.URL,
+		Feeds:   []string{"/hotentry/it.rss"},
+		HTTP:    HTTPConfig{Validators: NewMemoryValidatorStore()},
+	})
+
+	tags := []string{"Go", "Google Cloud", "Rust"}
+	results := make([][]string, len(tags))
+	errs := make([]error, len(tags))
+	var wg sync.WaitGroup
+	for i, tag := range tags {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			articles, err := f.Fetch(context.Background(), tag)
+			errs[i] = err
+			for _, a := range articles {
+				results[i] = append(results[i], a.URL)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("server received %d requests, want 1", n)
+	}
+	want := [][]string{
+		{"https://example.com/blog/go-1-23"},
+		{"https://example.com/blog/google-cloud-run"},
+		nil,
+	}
+	for i := range tags {
+		if errs[i] != nil {
+			t.Errorf("Fetch(%q) error = %v", tags[i], errs[i])
+		}
+		if len(results[i]) != len(want[i]) || (len(want[i]) > 0 && results[i][0] != want[i][0]) {
+			t.Errorf("Fetch(%q) = %v, want %v", tags[i], results[i], want[i])
+		}
+	}
+}
+
+func TestHatenaFetcherArticle(t *testing.T) {
+	var requests atomic.Int32
+	srv := newHatenaTestServer(t, &requests)
+	f := NewHatenaFetcher(HatenaConfig{BaseURL: srv.URL, Feeds: []string{"/hotentry/it.rss"}})
+
+	articles, err := f.Fetch(context.Background(), "Go")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != 1 {
+		t.Fatalf("Fetch() returned %d articles, want 1", len(articles))
+	}
+	a := articles[0]
+	if a.Title != "Go 1.23 の新機能まとめ" || a.Likes != 321 || a.Source != "Hatena Bookmark" {
+		t.Errorf("article = {%q %d %q}, want {Go 1.23 の新機能まとめ 321 Hatena Bookmark}", a.Title, a.Likes, a.Source)
+	}
+	if len(a.Tags) != 1 || a.Tags[0] != "Go" {
+		t.Errorf("Tags = %v, want [Go]", a.Tags)
+	}
+	if a.Author == nil || a.Author.Name != "hatena_user" {
+		t.Errorf("Author = %+v, want hatena_user", a.Author)
+	}
+}
+
+func TestHatenaFetcherNotModifiedKeepsItems(t *testing.T) {
+	var requests atomic.Int32
+	srv := newHatenaTestServer(t, &requests)
+	f := NewHatenaFetcher(HatenaConfig{
+		BaseURL: srv.URL,
+		Feeds:   []string{"/hotentry/it.rss"},
+		HTTP:    HTTPConfig{Validators: NewMemoryValidatorStore()},
+	})
+	// キャッシュを使わず毎回取得する
+	f.cache.ttl = 0
+
+	if _, err := f.Fetch(context.Background(), "Go"); err != nil {
+		t.Fatalf("first Fetch() error = %v", err)
+	}
+	if err := f.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if _, err := f.Fetch(context.Background(), "Go"); err != ErrNotModified {
+		t.Fatalf("second Fetch() error = %v, want ErrNotModified", err)
+	}
+	if e := f.cache.entry(srv.URL + "/hotentry/it.rss"); len(e.items) != 2 {
+		t.Errorf("cached items = %d after 304, want 2", len(e.items))
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("server received %d requests, want 2", n)
+	}
+}
+
+func TestHatenaFetcherDiscardsValidatorsWhenLaterFeedFails(t *testing.T) {
+	ctx := context.Background()
+	body, err := os.ReadFile("testdata/hatena_hotentry_it.rdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	failSecond := true
+	var firstConditional []bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/second.rss" && failSecond {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path == "/first.rss" {
+			firstConditional = append(firstConditional, r.Header.Get("If-None-Match") != "")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	f := NewHatenaFetcher(HatenaConfig{
+		BaseURL: srv.URL,
+		Feeds:   []string{"/first.rss", "/second.rss"},
+		HTTP:    HTTPConfig{Validators: NewMemoryValidatorStore()},
+	})
+	// 次の実行でフィードを取得し直すよう、キャッシュを使わない
+	f.cache.ttl = 0
+
+	if _, err := f.Fetch(ctx, ""); err == nil {
+		t.Fatal("Fetch() error = nil, want error for the second feed")
+	}
+	if err := f.Commit(ctx); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	mu.Lock()
+	failSecond = false
+	mu.Unlock()
+	if _, err := f.Fetch(ctx, ""); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(firstConditional) != 2 || firstConditional[1] {
+		t.Errorf("first feed conditional requests = %v, want the second run without If-None-Match", firstConditional)
+	}
+}
@@ -0,0 +1,160 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHTTPTimeout はFetcherのHTTPリクエストのデフォルトのタイムアウトです。
+	DefaultHTTPTimeout = 15 * time.Second
+	// DefaultUserAgent はFetcherが送信するデフォルトのUser-Agentです。
+	DefaultUserAgent = "TecheeBackEnd/1.0 (+https://techee-front-end.vercel.app)"
+)
+
+// HTTPConfig はFetcherが使用するHTTPクライアントの設定です。
+type HTTPConfig struct {
+	// Client はリクエストに使用するHTTPクライアントです。nilの場合はDefaultHTTPTimeoutのクライアントを使用します。
+	Client *http.Client
+	// UserAgent はリクエストに付与するUser-Agentです。空の場合はDefaultUserAgentを使用します。
+	UserAgent string
+	// Validators はETag/Last-Modifiedを保存するストアです。nilの場合は条件付きリクエストを行いません。
+	// 検証子は記事の保存に成功した後、FetcherのCommitで保存されます。
+	Validators ValidatorStore
+}
+
+// NewHTTPClient は指定したタイムアウトを持つHTTPクライアントを作成します。
+// maxPerHostが1以上の場合、ホストごとの同時リクエスト数をその値に制限します。
+func NewHTTPClient(timeout time.Duration, maxPerHost int) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewHostLimitedTransport(nil, maxPerHost),
+	}
+}
+
+// orDefault はcfgの未設定の項目をfallbackの値で補います。
+func (cfg HTTPConfig) orDefault(fallback HTTPConfig) HTTPConfig {
+	if cfg.Client == nil {
+		cfg.Client = fallback.Client
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = fallback.UserAgent
+	}
+	if cfg.Validators == nil {
+		cfg.Validators = fallback.Validators
+	}
+	return cfg
+}
+
+// requester は各Fetcherで共通のHTTPリクエスト処理です。
+type requester struct {
+	client     *http.Client
+	userAgent  string
+	validators ValidatorStore
+	pending    *pendingValidators
+}
+
+func newRequester(cfg HTTPConfig) requester {
+	r := requester{
+		client:     cfg.Client,
+		userAgent:  cfg.UserAgent,
+		validators: cfg.Validators,
+		pending: &pendingValidators{
+			validators: make(map[string]Validators),
+			discarded:  make(map[string]bool),
+		},
+	}
+	if r.client == nil {
+		r.client = NewHTTPClient(DefaultHTTPTimeout, 0)
+	}
+	if r.userAgent == "" {
+		r.userAgent = DefaultUserAgent
+	}
+	return r
+}
+
+// get はctxに紐づいたGETリクエストを実行します。headerは追加のリクエストヘッダーです。
+// 検証子が保存されている場合は条件付きリクエストとなり、304が返る可能性があります。
+func (r requester) get(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("User-Agent", r.userAgent)
+	r.setConditionalHeaders(ctx, url, req.Header)
+	return r.client.Do(req)
+}
+
+// hostLimitedTransport はホストごとの同時リクエスト数を制限するRoundTripperです。
+type hostLimitedTransport struct {
+	base    http.RoundTripper
+	perHost int
+
+	mu   sync.Mutex
+	sems map[string]chan struct{}
+}
+
+// NewHostLimitedTransport はホストごとの同時リクエスト数をperHostに制限するRoundTripperを作成します。
+// baseがnilの場合はhttp.DefaultTransportを使用します。
+func NewHostLimitedTransport(base http.RoundTripper, perHost int) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if perHost <= 0 {
+		return base
+	}
+	return &hostLimitedTransport{base: base, perHost: perHost, sems: make(map[string]chan struct{})}
+}
+
+func (t *hostLimitedTransport) semaphore(host string) chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sem, ok := t.sems[host]
+	if !ok {
+		sem = make(chan struct{}, t.perHost)
+		t.sems[host] = sem
+	}
+	return sem
+}
+
+// RoundTrip は空きができるまで待ってからリクエストを実行します。
+// 枠はレスポンスボディが閉じられた時点で解放されます。
+func (t *hostLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sem := t.semaphore(req.URL.Host)
+	select {
+	case sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := sync.OnceFunc(func() { <-sem })
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// releaseOnClose はCloseされたときにセマフォの枠を解放するレスポンスボディです。
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,190 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// roundTripFunc は関数をhttp.RoundTripperとして使う型です。
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// ホストごとの同時実行数を記録し、delayの後にレスポンスを返すRoundTripper
+type concurrencyRecorder struct {
+	delay time.Duration
+
+	mu       sync.Mutex
+	inFlight map[string]int
+	max      map[string]int
+}
+
+func (c *concurrencyRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	c.mu.Lock()
+	c.inFlight[host]++
+	c.max[host] = max(c.max[host], c.inFlight[host])
+	c.mu.Unlock()
+
+	time.Sleep(c.delay)
+
+	c.mu.Lock()
+	c.inFlight[host]--
+	c.mu.Unlock()
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Request: req}, nil
+}
+
+func TestHostLimitedTransportLimitsPerHost(t *testing.T) {
+	rec := &concurrencyRecorder{delay: 5 * time.Millisecond, inFlight: map[string]int{}, max: map[string]int{}}
+	client := &http.Client{Transport: NewHostLimitedTransport(rec, 2)}
+
+	var wg sync.WaitGroup
+	var failed atomic.Int32
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			host := "a.example.com"
+			if i%2 == 1 {
+				host = "b.example.com"
+			}
+			res, err := client.Get("http://" + host + "/")
+			if err != nil {
+				failed.Add(1)
+				return
+			}
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if n := failed.Load(); n != 0 {
+		t.Fatalf("%d requests failed", n)
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	for _, host := range []string{"a.example.com", "b.example.com"} {
+		if rec.max[host] > 2 {
+			t.Errorf("max concurrent requests to %s = %d, want at most 2", host, rec.max[host])
+		}
+	}
+}
+
+func TestHostLimitedTransportReleasesSlots(t *testing.T) {
+	failNext := true
+	var mu sync.Mutex
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failNext {
+			failNext = false
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Request: req}, nil
+	})
+	transport := NewHostLimitedTransport(base, 1)
+	newRequest := func(ctx context.Context) *http.Request {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+
+	// 失敗したリクエストの枠は解放される
+	if _, err := transport.RoundTrip(newRequest(context.Background())); err == nil {
+		t.Fatal("RoundTrip() error = nil, want error")
+	}
+
+	// ボディを閉じるまで枠を使い続け、その間の同じホストへのリクエストは待つ
+	res, err := transport.RoundTrip(newRequest(context.Background()))
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := transport.RoundTrip(newRequest(ctx)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("RoundTrip() while the slot is in use error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// ボディを閉じると枠が解放される（複数回閉じても枠は1つだけ解放する）
+	res.Body.Close()
+	res.Body.Close()
+	res, err = transport.RoundTrip(newRequest(context.Background()))
+	if err != nil {
+		t.Fatalf("RoundTrip() after Close error = %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := transport.RoundTrip(newRequest(ctx)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("RoundTrip() error = %v, want %v (slot released twice)", err, context.DeadlineExceeded)
+	}
+	res.Body.Close()
+}
+
+func TestHostLimitedTransportCancelledWaitersDoNotLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		started <- struct{}{}
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Request: req}, nil
+	})
+	transport := NewHostLimitedTransport(base, 1)
+
+	// 1件目が枠を使っている間に、待っているリクエストをキャンセルする
+	first := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		res, err := transport.RoundTrip(req)
+		if err == nil {
+			res.Body.Close()
+		}
+		first <- err
+	}()
+	<-started
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/", nil)
+			_, err := transport.RoundTrip(req)
+			errs <- err
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("cancelled RoundTrip() error = %v, want %v", err, context.Canceled)
+		}
+	}
+
+	close(release)
+	if err := <-first; err != nil {
+		t.Fatalf("first RoundTrip() error = %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines = %d, want at most %d", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,236 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+const (
+	// DefaultOGPWorkers はOGP情報を並行して取得する数のデフォルト値です。
+	DefaultOGPWorkers = 4
+	// DefaultOGPTimeout は記事ページ1件の取得のタイムアウトのデフォルト値です。
+	DefaultOGPTimeout = 5 * time.Second
+
+	// OGPは<head>内にあるため、ページの先頭のみを読み込む
+	ogpMaxBodyBytes = 512 << 10
+	// 取得に失敗したURLを再試行するまでの時間
+	ogpFailureTTL = 6 * time.Hour
+)
+
+// OGP は記事ページのOpen Graphのメタデータです。
+type OGP struct {
+	Image       string
+	Description string
+	SiteName    string
+}
+
+// OGPConfig はOGPEnricherの設定です。
+type OGPConfig struct {
+	// Workers はページを並行して取得する数です。0以下の場合はデフォルト値を使用します。
+	Workers int
+	// HTTP はHTTPクライアントの設定です。ホストごとの同時接続数はクライアント側で制限します。
+	// 記事のURLは外部から与えられるため、ClientはNewPublicHTTPClientで作成したものを使います。
+	// Clientがnilの場合はDefaultOGPTimeoutのNewPublicHTTPClientを使用します。
+	HTTP HTTPConfig
+}
+
+// OGPEnricher は記事ページのOGPタグ（og:image, og:description, og:site_name）を読み取り、記事に付与します。
+// 取得結果はURLごとにキャッシュし、同じURLを繰り返し取得しません。
+type OGPEnricher struct {
+	workers int
+	http    requester
+
+	mu    sync.Mutex
+	cache map[string]ogpCacheEntry
+}
+
+type ogpCacheEntry struct {
+	ogp       OGP
+	err       error
+	fetchedAt time.Time
+}
+
+// NewOGPEnricher はOGPEnricherの新しいインスタンスを作成します。
+func NewOGPEnricher(cfg OGPConfig) *OGPEnricher {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = DefaultOGPWorkers
+	}
+	if cfg.HTTP.Client == nil {
+		cfg.HTTP.Client = NewPublicHTTPClient(DefaultOGPTimeout, 0)
+	}
+	// 記事ページは条件付きリクエストの対象外（キャッシュで再取得を防ぐ）
+	cfg.HTTP.Validators = nil
+	return &OGPEnricher{
+		workers: workers,
+		http:    newRequester(cfg.HTTP),
+		cache:   make(map[string]ogpCacheEntry),
+	}
+}
+
+// Enrich は記事ごとにOGP情報を取得し、サムネイル・説明・サイト名を設定します。
+// ページを取得できた記事は、OGPタグがない場合もOGPCheckedAtに取得日時を設定します。取得に失敗した記事はそのままにします。ctxがキャンセルされた場合は残りの記事の取得を中止します。
+func (e *OGPEnricher) Enrich(ctx context.Context, articles []model.Article) {
+	// 同じURLの記事は1回だけ取得する
+	var urls []string
+	indexes := make(map[string][]int)
+	for i, a := range articles {
+		if a.URL == "" {
+			continue
+		}
+		if _, ok := indexes[a.URL]; !ok {
+			urls = append(urls, a.URL)
+		}
+		indexes[a.URL] = append(indexes[a.URL], i)
+	}
+
+	jobs := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < min(e.workers, len(urls)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pageURL := range jobs {
+				ogp, err := e.Fetch(ctx, pageURL)
+				if err != nil {
+					continue
+				}
+				checkedAt := time.Now()
+				mu.Lock()
+				for _, i := range indexes[pageURL] {
+					articles[i].ThumbnailURL = ogp.Image
+					articles[i].Description = ogp.Description
+					articles[i].SiteName = ogp.SiteName
+					articles[i].OGPCheckedAt = checkedAt
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, pageURL := range urls {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- pageURL
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// Fetch はページのOGP情報を返します。キャッシュがあればそれを使います。
+func (e *OGPEnricher) Fetch(ctx context.Context, pageURL string) (OGP, error) {
+	e.mu.Lock()
+	cached, ok := e.cache[pageURL]
+	e.mu.Unlock()
+	if ok && (cached.err == nil || time.Since(cached.fetchedAt) < ogpFailureTTL) {
+		return cached.ogp, cached.err
+	}
+
+	ogp, err := e.fetch(ctx, pageURL)
+	if ctx.Err() != nil {
+		// キャンセルによる失敗はキャッシュしない
+		return ogp, err
+	}
+
+	e.mu.Lock()
+	e.cache[pageURL] = ogpCacheEntry{ogp: ogp, err: err, fetchedAt: time.Now()}
+	e.mu.Unlock()
+	return ogp, err
+}
+
+func (e *OGPEnricher) fetch(ctx context.Context, pageURL string) (OGP, error) {
+	res, err := e.http.get(ctx, pageURL, http.Header{"Accept": {"text/html"}})
+	if err != nil {
+		return OGP{}, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return OGP{}, fmt.Errorf("page returned non-200 status: %d", res.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return OGP{}, fmt.Errorf("page is not HTML: %s", mediaType)
+	}
+
+	ogp := parseOGP(io.LimitReader(res.Body, ogpMaxBodyBytes))
+
+	// og:imageが相対URLの場合はページのURLを基準に解決する
+	if ogp.Image != "" {
+		if base, err := url.Parse(pageURL); err == nil {
+			if ref, err := url.Parse(ogp.Image); err == nil {
+				ogp.Image = base.ResolveReference(ref).String()
+			}
+		}
+	}
+	return ogp, nil
+}
+
+// HTMLの<meta>タグからOGP情報を読み取る。og:descriptionがない場合はdescriptionを使う
+func parseOGP(r io.Reader) OGP {
+	var ogp OGP
+	var fallbackDescription string
+
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if ogp.Description == "" {
+				ogp.Description = fallbackDescription
+			}
+			return ogp
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				// OGPは<head>内にあるため、<body>以降は読まない
+				if ogp.Description == "" {
+					ogp.Description = fallbackDescription
+				}
+				return ogp
+			case "meta":
+				if !hasAttr {
+					continue
+				}
+				var property, content string
+				for {
+					key, val, more := z.TagAttr()
+					switch string(key) {
+					case "property", "name":
+						property = strings.ToLower(string(val))
+					case "content":
+						content = strings.TrimSpace(string(val))
+					}
+					if !more {
+						break
+					}
+				}
+				switch property {
+				case "og:image":
+					if ogp.Image == "" {
+						ogp.Image = content
+					}
+				case "og:description":
+					ogp.Description = content
+				case "og:site_name":
+					ogp.SiteName = content
+				case "description":
+					fallbackDescription = content
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+func TestOGPEnricherEnrich(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ogp":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<html><head>
+<meta property="og:image" content="/images/ogp.png">
+<meta property="og:description" content="OGPの説明">
+<meta property="og:site_name" content="Example">
+</head><body></body></html>`))
+		case "/no-ogp":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><head><title>No OGP</title></head></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	// テスト用のサーバーはループバックアドレスのため、接続先を制限しないクライアントを使う
+	e := NewOGPEnricher(OGPConfig{HTTP: HTTPConfig{Client: srv.Client()}})
+	articles := []model.Article{
+		{ID: "ogp", URL: srv.URL + "/ogp"},
+		{ID: "no-ogp", URL: srv.URL + "/no-ogp"},
+		{ID: "missing", URL: srv.URL + "/missing"},
+	}
+	before := time.Now()
+	e.Enrich(context.Background(), articles)
+
+	if a := articles[0]; a.ThumbnailURL != srv.URL+"/images/ogp.png" || a.Description != "OGPの説明" || a.SiteName != "Example" {
+		t.Errorf("ogp article = {%q %q %q}, want the page OGP", a.ThumbnailURL, a.Description, a.SiteName)
+	}
+	// OGPタグがなくても、ページを確認した日時を記録する
+	for _, a := range articles[:2] {
+		if a.OGPCheckedAt.Before(before) {
+			t.Errorf("%s OGPCheckedAt = %v, want the time of the fetch", a.ID, a.OGPCheckedAt)
+		}
+	}
+	// 取得に失敗したページは確認済みにしない
+	if a := articles[2]; !a.OGPCheckedAt.IsZero() || a.SiteName != "" {
+		t.Errorf("missing article = %+v, want unchanged", a)
+	}
+}
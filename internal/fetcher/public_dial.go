@@ -0,0 +1,81 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// errNonPublicAddress は公開されていないアドレスへの接続を拒否したことを表します。
+var errNonPublicAddress = errors.New("connection to a non-public address is not allowed")
+
+// IsPrivateなどで判定できない、インターネットから到達できないアドレスの範囲
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // 「このネットワーク」
+	netip.MustParsePrefix("100.64.0.0/10"), // キャリアグレードNAT
+	netip.MustParsePrefix("198.18.0.0/15"), // ベンチマーク用
+	netip.MustParsePrefix("240.0.0.0/4"),   // 予約済み（ブロードキャストを含む）
+}
+
+// NewPublicHTTPClient はNewHTTPClientと同様のHTTPクライアントを作成します。
+// 記事ページなど外部から指定されたURLを取得するためのもので、ループバック・プライベート・リンクローカルなど
+// 公開されていないアドレスへの接続を拒否します（SSRF対策）。
+// 接続する直前に名前解決後のアドレスを確認するため、リダイレクト先やDNSの応答が変わった場合も拒否します。
+func NewPublicHTTPClient(timeout time.Duration, maxPerHost int) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewHostLimitedTransport(newPublicTransport(), maxPerHost),
+	}
+}
+
+func newPublicTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	// プロキシ経由ではプロキシへの接続しか確認できないため、プロキシは使わない
+	t.Proxy = nil
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   checkPublicAddress,
+	}
+	t.DialContext = dialer.DialContext
+	return t
+}
+
+// 接続先のアドレスが公開されたアドレスでなければエラーを返す（net.DialerのControl）
+func checkPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	if !isPublicAddr(ip) {
+		return fmt.Errorf("%w: %s", errNonPublicAddress, ip)
+	}
+	return nil
+}
+
+// isPublicAddr はインターネットから到達できるユニキャストのアドレスかどうかを返します。
+func isPublicAddr(ip netip.Addr) bool {
+	// IPv4射影アドレス（::ffff:127.0.0.1など）はIPv4として判定する
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,11 +1,17 @@
 package fetcher
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
@@ -18,40 +24,347 @@ type qiitaArticle struct {
 	URL        string `json:"url"`
 	LikesCount int    `json:"likes_count"`
 	CreatedAt  string `json:"created_at"` // ISO 8601 format
-	Tags       []struct {
+	Body       string `json:"body"`       // Markdown形式の本文
+	User       struct {
+		ID              string `json:"id"`
+		Name            string `json:"name"`
+		ProfileImageURL string `json:"profile_image_url"`
+	} `json:"user"`
+	Tags []struct {
 		Name string `json:"name"`
 	} `json:"tags"`
 }
 
-// Qiita APIから記事を取得する関数
-func FetchQiitaArticles(tag string) ([]model.Article, error) {
-	baseURL := "https://qiita.com/api/v2/items"
+const (
+	// DefaultQiitaBaseURL はQiita APIのベースURLです。
+	DefaultQiitaBaseURL = "https://qiita.com/api/v2"
 
+	qiitaMaxPerPage = 100 // Qiita APIのper_pageの上限
+	qiitaMaxPage    = 100 // Qiita APIのpageの上限
+
+	// DefaultQiitaMaxArticlesPerTag はタグごとに取得する記事数のデフォルト値です。
+	DefaultQiitaMaxArticlesPerTag = 100
+	// DefaultQiitaInitialWindow はインクリメンタルモードで初回に取得する新着記事の期間のデフォルト値です。
+	DefaultQiitaInitialWindow = 7 * 24 * time.Hour
+
+	qiitaMaxRetries     = 3               // 429/5xx時の最大リトライ回数
+	qiitaRetryBaseDelay = 2 * time.Second // リトライ間隔の初期値（指数的に増加）
+	qiitaRateReserve    = 1               // 残りリクエスト数がこの値以下になったらリセットまで待機
+	qiitaRateSlowdown   = 10              // 残りリクエスト数がこの値以下になったらリクエスト間隔を空ける
+
+	// 人気記事とみなすストック数の下限。Qiita APIの/itemsは並び順を指定できないため、検索クエリで絞り込む
+	qiitaPopularMinStocks = 10
+)
+
+// QiitaConfig はQiitaFetcherの設定です。
+type QiitaConfig struct {
+	// MaxArticlesPerTag はタグごとに取得する最大記事数です。0以下の場合はデフォルト値を使用します。
+	MaxArticlesPerTag int
+	// AccessToken はQiita APIのアクセストークンです。空の場合は未認証（60回/時）でアクセスします。
+	AccessToken string
+	// Incremental がtrueの場合、人気記事に加えて前回の取得以降に作成された新着記事も取得します。
+	Incremental bool
+	// InitialWindow はインクリメンタルモードで前回の取得日時がない場合に取得する新着記事の期間です。
+	// 0以下の場合はデフォルト値を使用します。
+	InitialWindow time.Duration
+	// Checkpoints はタグごとの前回の取得日時の保存先です。nilの場合はメモリ上に保存します。
+	Checkpoints CheckpointStore
+	// BaseURL はQiita APIのベースURLです。空の場合はDefaultQiitaBaseURLを使用します。テスト用に差し替えられます。
+	BaseURL string
+	// HTTP はHTTPクライアントの設定です。
+	HTTP HTTPConfig
+}
+
+// QiitaFetcher はQiita APIから記事を取得するFetcherです。
+// レスポンスのRate-Remaining/Rate-Resetヘッダーを記録し、上限に達する前に待機します。
+type QiitaFetcher struct {
+	maxPerTag     int
+	accessToken   string
+	incremental   bool
+	initialWindow time.Duration
+	checkpoints   CheckpointStore
+	baseURL       string
+	http          requester
+
+	mu        sync.Mutex
+	rateLimit RateLimit
+	rateKnown bool
+	pending   map[string]time.Time // Commitで保存するタグごとの取得日時
+
+	// backfills は新着記事が最大記事数を超えたタグの、次回以降に取得する残りの範囲です。
+	// メモリ上にのみ保持するため、再起動した場合は取得日時から取得し直します。
+	backfills        map[string]qiitaBackfill
+	pendingBackfills map[string]qiitaBackfill // Commitで反映する残りの範囲（ゼロ値は取得の完了）
+}
+
+// qiitaBackfill は新着記事の取得で最大記事数を超えたため、取り残した古い記事の範囲です。
+// 結果は新しい順のため、取得済みの最も古い記事の作成日以前に絞り込んで続きを取得します。
+type qiitaBackfill struct {
+	until     time.Time // 取得済みの最も古い記事の作成日時
+	startedAt time.Time // 取り残しが発生した実行の開始日時。すべて取得した後の取得日時になる
+}
+
+// NewQiitaFetcher はQiitaFetcherの新しいインスタンスを作成します。
+func NewQiitaFetcher(cfg QiitaConfig) *QiitaFetcher {
+	maxPerTag := cfg.MaxArticlesPerTag
+	if maxPerTag <= 0 {
+		maxPerTag = DefaultQiitaMaxArticlesPerTag
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultQiitaBaseURL
+	}
+	initialWindow := cfg.InitialWindow
+	if initialWindow <= 0 {
+		initialWindow = DefaultQiitaInitialWindow
+	}
+	checkpoints := cfg.Checkpoints
+	if checkpoints == nil {
+		checkpoints = NewMemoryCheckpointStore()
+	}
+	return &QiitaFetcher{
+		maxPerTag:     maxPerTag,
+		accessToken:   cfg.AccessToken,
+		incremental:   cfg.Incremental,
+		initialWindow: initialWindow,
+		checkpoints:   checkpoints,
+		baseURL:       strings.TrimSuffix(baseURL, "/"),
+		http:          newRequester(cfg.HTTP),
+		pending:       make(map[string]time.Time),
+
+		backfills:        make(map[string]qiitaBackfill),
+		pendingBackfills: make(map[string]qiitaBackfill),
+	}
+}
+
+// Name はソース名を返します。
+func (f *QiitaFetcher) Name() string {
+	return "qiita"
+}
+
+// Commit は記事の保存に成功した後に呼び出し、タグごとの取得日時と取得したレスポンスの検証子を保存します。
+func (f *QiitaFetcher) Commit(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var errs []error
+	for tag, t := range f.pending {
+		if err := f.checkpoints.SetLastFetched(ctx, f.Name(), tag, t); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save Qiita checkpoint for tag %s: %w", tag, err))
+			continue
+		}
+		delete(f.pending, tag)
+	}
+	for tag, b := range f.pendingBackfills {
+		if b.until.IsZero() {
+			delete(f.backfills, tag)
+		} else {
+			f.backfills[tag] = b
+		}
+		delete(f.pendingBackfills, tag)
+	}
+	if err := f.http.commitValidators(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Fetch は指定したタグのQiita記事を取得します。
+// ストック数の多い人気記事に加え、インクリメンタルモードでは前回の取得以降に作成された新着記事も取得します。
+// 新着記事が最大記事数を超えた場合は、次回以降の実行で残りの古い記事を取得してから取得日時を進めます。
+// 取得日時はCommitで保存されるまで記録のみ行います。
+func (f *QiitaFetcher) Fetch(ctx context.Context, tag string) ([]model.Article, error) {
+	// 途中のページや新着記事の取得で失敗した場合は、取得済みのページの検証子も保存しない
+	batch := validatorBatch{}
+	articles, err := f.fetch(ctx, tag, batch)
+	f.http.keepValidators(batch, err)
+	return articles, err
+}
+
+func (f *QiitaFetcher) fetch(ctx context.Context, tag string, batch validatorBatch) ([]model.Article, error) {
+	startedAt := time.Now()
+
+	// 人気記事（ストック数が一定以上のもの）
+	popularQuery := strings.TrimSpace(fmt.Sprintf("%s stocks:>%d", qiitaTagQuery(tag), qiitaPopularMinStocks))
+	popular, _, popularErr := f.fetchPages(ctx, batch, popularQuery)
+	if popularErr != nil && !errors.Is(popularErr, ErrNotModified) {
+		return nil, fmt.Errorf("popular articles: %w", popularErr)
+	}
+	if !f.incremental {
+		return popular, popularErr
+	}
+
+	// 新着記事（前回の取得日以降に作成されたもの）
+	since := startedAt.Add(-f.initialWindow)
+	last, ok, err := f.checkpoints.LastFetched(ctx, f.Name(), tag)
+	if err != nil {
+		log.Printf("Warning: failed to load Qiita checkpoint for tag %s: %v", tag, err)
+	} else if ok {
+		since = last
+	}
+	// created: は日付単位の指定のため、同じ日の記事は重複して取得される（保存時に上書きされる）
+	query := strings.TrimSpace(qiitaTagQuery(tag) + " created:>=" + qiitaDate(since))
+	f.mu.Lock()
+	backfill, backfilling := f.backfills[tag]
+	f.mu.Unlock()
+	if backfilling {
+		// 前回までに取得した記事より古い記事の続きを取得する
+		query += " created:<=" + qiitaDate(backfill.until)
+	}
+	recent, truncated, recentErr := f.fetchPages(ctx, batch, query)
+	if recentErr != nil && !errors.Is(recentErr, ErrNotModified) {
+		return nil, fmt.Errorf("recent articles: %w", recentErr)
+	}
+	f.recordRecentProgress(tag, startedAt, backfill, backfilling, recent, truncated)
+
+	if popularErr != nil && recentErr != nil {
+		// どちらも変更なし
+		return nil, ErrNotModified
+	}
+	return mergeArticles(popular, recent), nil
+}
+
+// 新着記事の取得結果から、Commitで保存する取得日時と残りの範囲を記録する
+// 最大記事数で打ち切った場合は、取得日時を進めずに取得済みの最も古い記事より前を次回取得する
+func (f *QiitaFetcher) recordRecentProgress(tag string, startedAt time.Time, backfill qiitaBackfill, backfilling bool, recent []model.Article, truncated bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	checkpoint := startedAt
+	// 検索の上限の日付（絞り込んでいない場合は実行日）
+	upper := startedAt
+	if backfilling {
+		checkpoint = backfill.startedAt
+		upper = backfill.until
+	}
+	if truncated {
+		oldest := oldestPublishedAt(recent)
+		// 同じ日付に最大記事数を超える記事がある場合は、日付単位の絞り込みでは続きを取得できない
+		if !oldest.IsZero() && qiitaDate(oldest) < qiitaDate(upper) {
+			f.pendingBackfills[tag] = qiitaBackfill{until: oldest, startedAt: checkpoint}
+			return
+		}
+		log.Printf("Warning: Qiita recent articles for tag %s exceeded %d articles on %s, skipping the rest",
+			tag, f.maxPerTag, qiitaDate(upper))
+	}
+	f.pending[tag] = checkpoint
+	f.pendingBackfills[tag] = qiitaBackfill{}
+}
+
+// 作成日時が最も古い記事の作成日時（不明な記事は除く）
+func oldestPublishedAt(articles []model.Article) time.Time {
+	var oldest time.Time
+	for _, a := range articles {
+		if !a.PublishedAt.IsZero() && (oldest.IsZero() || a.PublishedAt.Before(oldest)) {
+			oldest = a.PublishedAt
+		}
+	}
+	return oldest
+}
+
+// 検索クエリのcreated:に指定する日付
+func qiitaDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// 最大記事数に達するか結果がなくなるまでページをたどって記事を取得する
+// すべてのページが前回から変更なしの場合はErrNotModifiedを返す。
+// 最大記事数に達して残りの記事を取得しなかった可能性がある場合はtruncatedにtrueを返す
+func (f *QiitaFetcher) fetchPages(ctx context.Context, batch validatorBatch, query string) (articles []model.Article, truncated bool, err error) {
+	perPage := min(f.maxPerTag, qiitaMaxPerPage)
+
+	covered := 0   // 取得済み（変更なしのページを含む）の記事数
+	unchanged := 0 // 前回から変更がなかったページ数
+	lastPage := false
+	for page := 1; page <= qiitaMaxPage && covered < f.maxPerTag; page++ {
+		pageArticles, err := f.fetchPage(ctx, batch, query, page, perPage)
+		if errors.Is(err, ErrNotModified) {
+			// 変更のないページは件数が分からないため、per_page件あったものとして次のページへ進む
+			unchanged++
+			covered += perPage
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("page %d: %w", page, err)
+		}
+		articles = append(articles, pageArticles...)
+		covered += len(pageArticles)
+
+		// 取得件数がper_pageに満たなければ最後のページ
+		if len(pageArticles) < perPage {
+			lastPage = true
+			break
+		}
+	}
+
+	if len(articles) == 0 && unchanged > 0 {
+		return nil, false, ErrNotModified
+	}
+	truncated = !lastPage
+	if len(articles) > f.maxPerTag {
+		articles = articles[:f.maxPerTag]
+	}
+	return articles, truncated, nil
+}
+
+// タグで絞り込む検索クエリ
+func qiitaTagQuery(tag string) string {
+	if tag == "" {
+		return ""
+	}
+	return fmt.Sprintf("tag:%s", tag)
+}
+
+// IDが重複する記事を除いて結合する（先に現れたものを優先）
+func mergeArticles(lists ...[]model.Article) []model.Article {
+	var merged []model.Article
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, a := range list {
+			if seen[a.ID] {
+				continue
+			}
+			seen[a.ID] = true
+			merged = append(merged, a)
+		}
+	}
+	return merged
+}
+
+// RateLimit は直近のレスポンスから得たQiita APIの残りリクエスト数を返します。
+// まだリクエストしていない場合はfalseを返します。
+func (f *QiitaFetcher) RateLimit() (RateLimit, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rateLimit, f.rateKnown
+}
+
+// Qiita APIから1ページ分の記事を取得する
+func (f *QiitaFetcher) fetchPage(ctx context.Context, batch validatorBatch, query string, page, perPage int) ([]model.Article, error) {
 	// クエリパラメータを設定
 	params := url.Values{}
-	params.Add("sort", "likes") // いいね数でソート
-	if tag != "" {
-		params.Add("query", fmt.Sprintf("tag:%s", tag)) // タグで絞り込み
+	params.Add("page", strconv.Itoa(page))
+	params.Add("per_page", strconv.Itoa(perPage))
+	if query != "" {
+		params.Add("query", query) // タグ・ストック数・作成日で絞り込み
 	}
 
 	// リクエストURLを構築
-	reqURL, err := url.Parse(baseURL)
+	reqURL, err := url.Parse(f.baseURL + "/items")
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
 	reqURL.RawQuery = params.Encode()
 
-	// HTTP GET リクエストを実行
-	res, err := http.Get(reqURL.String())
+	// HTTP GET リクエストを実行（レート制限とリトライを考慮）
+	res, err := f.get(ctx, reqURL.String())
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch Qiita articles: %w", err)
+		return nil, err
 	}
 	defer res.Body.Close()
 
-	// ステータスコードを確認
-	if res.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("Qiita API returned non-200 status: %d, body: %s", res.StatusCode, bodyBytes)
+	// 前回の取得から変更がない
+	if res.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
 	}
 
 	// レスポンスボディを読み込み
@@ -66,6 +379,7 @@ func FetchQiitaArticles(tag string) ([]model.Article, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal Qiita API response: %w", err)
 	}
+	batch.record(reqURL.String(), res.Header)
 
 	// 内部モデルにマッピング
 	articles := make([]model.Article, 0, len(qiitaArticles))
@@ -77,24 +391,141 @@ func FetchQiitaArticles(tag string) ([]model.Article, error) {
 
 		publishedAt, err := time.Parse(time.RFC3339, qa.CreatedAt)
 		if err != nil {
-			// パースエラーの場合はスキップまたはエラー処理を検討
-			fmt.Printf("Warning: failed to parse Qiita article created_at '%s': %v\n", qa.CreatedAt, err)
-			// エラーで中断せず、次の記事に進む
-			// publishedAtがパースできなかった場合はゼロ値または適切なデフォルト値を使用
+			// 1件の日時が不正でも取得全体は失敗させず、公開日時なしとして扱う
+			log.Printf("Warning: failed to parse Qiita article %s created_at %q: %v", qa.ID, qa.CreatedAt, err)
 			publishedAt = time.Time{}
 		}
 
+		excerpt, wordCount := excerptFromMarkdown(qa.Body)
+
 		articles = append(articles, model.Article{
 			ID:          qa.ID,
 			Title:       qa.Title,
 			URL:         qa.URL,
 			Tags:        tags,
 			Likes:       qa.LikesCount,
-			PublishedAt: publishedAt.Format(time.RFC3339), // time.Timeをstringにフォーマット（二重定義を解消）
+			PublishedAt: publishedAt,
 			Source:      "Qiita",
-			// FetchedAtは記事取得時に設定（後で実装）
+			Excerpt:     excerpt,
+			WordCount:   wordCount,
+			Author:      qiitaAuthor(qa),
+			// FetchedAtは保存時にサービス層で設定
 		})
 	}
 
 	return articles, nil
 }
+
+// レート制限を考慮してGETリクエストを実行する。429/5xxの場合は間隔を空けてリトライする。
+// 戻り値のレスポンスはステータス200または304で、ボディは呼び出し元で閉じる必要がある。
+func (f *QiitaFetcher) get(ctx context.Context, reqURL string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := f.waitForQuota(ctx); err != nil {
+			return nil, err
+		}
+
+		header := http.Header{}
+		if f.accessToken != "" {
+			header.Set("Authorization", "Bearer "+f.accessToken)
+		}
+
+		res, err := f.http.get(ctx, reqURL, header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch Qiita articles: %w", err)
+		}
+		f.updateRateLimit(res.Header)
+
+		if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusNotModified {
+			return res, nil
+		}
+
+		bodyBytes, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		statusErr := fmt.Errorf("Qiita API returned non-200 status: %d, body: %s", res.StatusCode, bodyBytes)
+
+		// 429と5xxのみリトライ対象
+		retryable := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+		if !retryable || attempt >= qiitaMaxRetries {
+			return nil, statusErr
+		}
+
+		delay := qiitaRetryBaseDelay << attempt
+		if d, ok := retryAfter(res.Header); ok {
+			delay = d
+		}
+		log.Printf("Qiita API returned %d, retrying in %s (attempt %d/%d)", res.StatusCode, delay, attempt+1, qiitaMaxRetries)
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, fmt.Errorf("%v (retry aborted: %w)", statusErr, err)
+		}
+	}
+}
+
+// レスポンスヘッダーから残りリクエスト数とリセット時刻を記録する
+func (f *QiitaFetcher) updateRateLimit(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("Rate-Remaining"))
+	if err != nil {
+		return
+	}
+	rl := RateLimit{Remaining: remaining}
+	if reset, err := strconv.ParseInt(h.Get("Rate-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	if limit, err := strconv.Atoi(h.Get("Rate-Limit")); err == nil {
+		rl.Limit = limit
+	}
+
+	f.mu.Lock()
+	f.rateLimit = rl
+	f.rateKnown = true
+	f.mu.Unlock()
+}
+
+// 残りリクエスト数が少ない場合、リセット時刻まで待機する、またはリクエスト間隔を空ける
+func (f *QiitaFetcher) waitForQuota(ctx context.Context) error {
+	rl, ok := f.RateLimit()
+	if !ok || rl.Reset.IsZero() || rl.Remaining > qiitaRateSlowdown {
+		return nil
+	}
+	untilReset := time.Until(rl.Reset)
+	if untilReset <= 0 {
+		return nil
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	if rl.Remaining <= qiitaRateReserve {
+		// 上限に達する前にリセットまで待機する。期限までにリセットされない場合は待たずに失敗させる
+		if hasDeadline && time.Until(deadline) < untilReset {
+			return fmt.Errorf("Qiita rate limit exhausted (%d remaining) until %s", rl.Remaining, rl.Reset.Format(time.RFC3339))
+		}
+		log.Printf("Qiita rate limit nearly exhausted (%d remaining), waiting %s until reset", rl.Remaining, untilReset.Round(time.Second))
+		return sleepContext(ctx, untilReset)
+	}
+
+	// 残りのリクエストをリセットまでの時間で均等に使う。
+	// 期限の方が早い場合は、期限までの時間で均等に使うよう間隔を縮める
+	span := untilReset
+	if hasDeadline {
+		span = min(span, time.Until(deadline))
+	}
+	if span <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, span/time.Duration(rl.Remaining))
+}
+
+// Qiitaのユーザー情報を著者にマッピングする
+func qiitaAuthor(qa qiitaArticle) *model.Author {
+	if qa.User.ID == "" {
+		return nil
+	}
+	name := qa.User.Name
+	if name == "" {
+		name = qa.User.ID
+	}
+	return &model.Author{
+		ID:         qa.User.ID,
+		Name:       name,
+		AvatarURL:  qa.User.ProfileImageURL,
+		ProfileURL: "https://qiita.com/" + qa.User.ID,
+	}
+}
@@ -0,0 +1,309 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+func TestQiitaFetcherWaitForQuota(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining int
+		reset     time.Duration
+		deadline  time.Duration
+		wantErr   bool
+		maxWait   time.Duration
+	}{
+		{name: "十分な残り", remaining: 50, reset: time.Hour, deadline: time.Second, maxWait: 50 * time.Millisecond},
+		{name: "間隔を空ける場合は期限内に収める", remaining: 5, reset: time.Hour, deadline: 500 * time.Millisecond, maxWait: 200 * time.Millisecond},
+		{name: "期限までにリセットされない", remaining: 1, reset: time.Hour, deadline: time.Second, wantErr: true, maxWait: 50 * time.Millisecond},
+		{name: "期限内にリセットされる", remaining: 0, reset: 100 * time.Millisecond, deadline: time.Second, maxWait: 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewQiitaFetcher(QiitaConfig{})
+			f.rateLimit = RateLimit{Remaining: tt.remaining, Reset: time.Now().Add(tt.reset)}
+			f.rateKnown = true
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.deadline)
+			defer cancel()
+
+			start := time.Now()
+			err := f.waitForQuota(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("waitForQuota() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > tt.maxWait {
+				t.Errorf("waitForQuota() waited %s, want at most %s", elapsed, tt.maxWait)
+			}
+		})
+	}
+}
+
+// 新着記事のリクエスト（created:で絞り込んだもの）にrecent件の記事を返すQiita APIのテスト用サーバー
+func newQiitaTestServer(t *testing.T, recent int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items := []qiitaArticle{}
+		if strings.Contains(r.URL.Query().Get("query"), "created:") && r.URL.Query().Get("page") == "1" {
+			for i := range recent {
+				items = append(items, qiitaArticle{
+					ID:        fmt.Sprintf("recent%d", i),
+					Title:     "Go",
+					URL:       fmt.Sprintf("https://qiita.com/gopher/items/recent%d", i),
+					CreatedAt: "2024-07-01T10:00:00+09:00",
+				})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(items)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestQiitaFetcherCheckpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		recent    int
+		wantSaved bool
+	}{
+		{name: "すべての新着記事を取得", recent: 3, wantSaved: true},
+		{name: "最大記事数で打ち切り", recent: 5, wantSaved: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := newQiitaTestServer(t, tt.recent)
+			checkpoints := NewMemoryCheckpointStore()
+			f := NewQiitaFetcher(QiitaConfig{
+				BaseURL:           srv.URL,
+				Incremental:       true,
+				MaxArticlesPerTag: 5,
+				Checkpoints:       checkpoints,
+			})
+
+			articles, err := f.Fetch(ctx, "Go")
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if len(articles) != tt.recent {
+				t.Errorf("Fetch() returned %d articles, want %d", len(articles), tt.recent)
+			}
+			// 記事が保存されるまでは取得日時を記録しない
+			if _, ok, _ := checkpoints.LastFetched(ctx, "qiita", "Go"); ok {
+				t.Fatal("checkpoint saved before Commit")
+			}
+
+			if err := f.Commit(ctx); err != nil {
+				t.Fatalf("Commit() error = %v", err)
+			}
+			if _, ok, _ := checkpoints.LastFetched(ctx, "qiita", "Go"); ok != tt.wantSaved {
+				t.Errorf("checkpoint saved = %v after Commit, want %v", ok, tt.wantSaved)
+			}
+		})
+	}
+}
+
+func TestQiitaFetcherDiscardsValidatorsWhenLaterPageFails(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	failPage2 := true
+	var page1Conditional []bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Query().Get("page") {
+		case "1":
+			page1Conditional = append(page1Conditional, r.Header.Get("If-None-Match") != "")
+			// 次のページへ進むよう、per_page件を返す
+			items := make([]qiitaArticle, qiitaMaxPerPage)
+			for i := range items {
+				items[i] = qiitaArticle{
+					ID:        fmt.Sprintf("item%d", i),
+					Title:     "Go",
+					URL:       fmt.Sprintf("https://qiita.com/gopher/items/item%d", i),
+					CreatedAt: "2024-07-01T10:00:00+09:00",
+				}
+			}
+			w.Header().Set("ETag", `"page1"`)
+			json.NewEncoder(w).Encode(items)
+		default:
+			if failPage2 {
+				// リトライしないステータスで失敗させる
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode([]qiitaArticle{})
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	f := NewQiitaFetcher(QiitaConfig{
+		BaseURL:           srv.URL,
+		MaxArticlesPerTag: qiitaMaxPerPage * 2,
+		HTTP:              HTTPConfig{Validators: NewMemoryValidatorStore()},
+	})
+
+	if _, err := f.Fetch(ctx, "Go"); err == nil {
+		t.Fatal("Fetch() error = nil, want error for page 2")
+	}
+	// 他のソースの記事の保存に成功した場合もCommitは呼ばれる
+	if err := f.Commit(ctx); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	mu.Lock()
+	failPage2 = false
+	mu.Unlock()
+	articles, err := f.Fetch(ctx, "Go")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(articles) != qiitaMaxPerPage {
+		t.Errorf("Fetch() returned %d articles, want %d", len(articles), qiitaMaxPerPage)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(page1Conditional) != 2 || page1Conditional[1] {
+		t.Errorf("page 1 conditional requests = %v, want the second run without If-None-Match", page1Conditional)
+	}
+}
+
+func TestQiitaFetcherBackfillsTruncatedRecentArticles(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var recentQueries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items := []qiitaArticle{}
+		if strings.Contains(r.URL.Query().Get("query"), "created:") {
+			query := r.URL.Query().Get("query")
+			mu.Lock()
+			recentQueries = append(recentQueries, query)
+			mu.Unlock()
+			// 絞り込みがなければ新しい5件（最大記事数）、続きの取得では残りの2件を新しい順に返す
+			days := []int{7, 6, 5, 4, 3}
+			if strings.Contains(query, "created:<=2024-07-03") {
+				days = []int{3, 2}
+			}
+			for _, d := range days {
+				items = append(items, qiitaArticle{
+					ID:        fmt.Sprintf("day%d", d),
+					Title:     "Go",
+					URL:       fmt.Sprintf("https://qiita.com/gopher/items/day%d", d),
+					CreatedAt: fmt.Sprintf("2024-07-%02dT10:00:00Z", d),
+				})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(items)
+	}))
+	t.Cleanup(srv.Close)
+
+	checkpoints := NewMemoryCheckpointStore()
+	since := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	if err := checkpoints.SetLastFetched(ctx, "qiita", "Go", since); err != nil {
+		t.Fatal(err)
+	}
+	f := NewQiitaFetcher(QiitaConfig{
+		BaseURL:           srv.URL,
+		Incremental:       true,
+		MaxArticlesPerTag: 5,
+		Checkpoints:       checkpoints,
+	})
+
+	// 1回目: 最大記事数で打ち切られるため、取得日時を進めない
+	firstStartedAt := time.Now()
+	if _, err := f.Fetch(ctx, "Go"); err != nil {
+		t.Fatalf("first Fetch() error = %v", err)
+	}
+	if err := f.Commit(ctx); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if last, _, _ := checkpoints.LastFetched(ctx, "qiita", "Go"); !last.Equal(since) {
+		t.Fatalf("checkpoint after first run = %v, want %v", last, since)
+	}
+
+	// 2回目: 取得済みの最も古い記事以前の続きを取得し、取得日時を1回目の開始日時まで進める
+	articles, err := f.Fetch(ctx, "Go")
+	if err != nil {
+		t.Fatalf("second Fetch() error = %v", err)
+	}
+	if err := f.Commit(ctx); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if !containsArticle(articles, "day2") {
+		t.Errorf("second Fetch() did not return the older article day2")
+	}
+	last, _, _ := checkpoints.LastFetched(ctx, "qiita", "Go")
+	if last.Before(firstStartedAt) || last.After(time.Now()) {
+		t.Errorf("checkpoint after second run = %v, want the start of the first run", last)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{
+		"tag:Go created:>=2024-07-01",
+		"tag:Go created:>=2024-07-01 created:<=2024-07-03",
+	}
+	if len(recentQueries) != len(want) {
+		t.Fatalf("recent queries = %q, want %q", recentQueries, want)
+	}
+	for i := range want {
+		if recentQueries[i] != want[i] {
+			t.Errorf("recent query %d = %q, want %q", i, recentQueries[i], want[i])
+		}
+	}
+}
+
+func containsArticle(articles []model.Article, id string) bool {
+	for _, a := range articles {
+		if a.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestQiitaFetcherPopularQuery(t *testing.T) {
+	var mu sync.Mutex
+	var params []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		params = append(params, r.URL.Query())
+		mu.Unlock()
+		json.NewEncoder(w).Encode([]qiitaArticle{})
+	}))
+	t.Cleanup(srv.Close)
+
+	f := NewQiitaFetcher(QiitaConfig{BaseURL: srv.URL})
+	if _, err := f.Fetch(context.Background(), "Go"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(params) != 1 {
+		t.Fatalf("got %d requests, want 1", len(params))
+	}
+	// /itemsはsortを受け付けないため、人気記事はストック数で絞り込む
+	if got, want := params[0].Get("query"), fmt.Sprintf("tag:Go stocks:>%d", qiitaPopularMinStocks); got != want {
+		t.Errorf("query = %q, want %q", got, want)
+	}
+	if params[0].Has("sort") {
+		t.Errorf("request has sort = %q, want none", params[0].Get("sort"))
+	}
+}
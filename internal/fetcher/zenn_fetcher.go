@@ -1,52 +1,162 @@
 package fetcher
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
 )
 
-// Zennから記事を取得する関数 (今回は簡易的に固定データを返す)
-func FetchZennArticles(tag string) ([]model.Article, error) {
-	// 実際にはZennのRSSフィードなどをパースして記事を取得
-	fmt.Printf("Fetching Zenn articles for tag: %s (using fixed data)\n", tag)
-
-	// 固定のサンプルデータを返す
-	articles := []model.Article{
-		{
-			ID:          "zenn-sample-1",
-			Title:       "Zennのサンプル記事1",
-			URL:         "https://zenn.dev/sample/1",
-			Tags:        []string{"Go", "Zenn"},
-			Likes:       100,
-			PublishedAt: time.Now().Add(-24 * time.Hour).Format(time.RFC3339), // stringにフォーマット
-			Source:      "Zenn",
-		},
-		{
-			ID:          "zenn-sample-2",
-			Title:       "Zennのサンプル記事2 (Python)",
-			URL:         "https://zenn.dev/sample/2",
-			Tags:        []string{"Python", "Zenn"},
-			Likes:       50,
-			PublishedAt: time.Now().Add(-48 * time.Hour).Format(time.RFC3339), // stringにフォーマット
-			Source:      "Zenn",
-		},
-	}
-
-	// タグでフィルタリング（固定データなので簡易フィルタリング）
-	if tag != "" {
-		filteredArticles := []model.Article{}
-		for _, article := range articles {
-			for _, articleTag := range article.Tags {
-				if articleTag == tag {
-					filteredArticles = append(filteredArticles, article)
-					break
-				}
+// DefaultZennBaseURL はZennのベースURLです。
+const DefaultZennBaseURL = "https://zenn.dev"
+
+// ZennConfig はZennFetcherの設定です。
+type ZennConfig struct {
+	// BaseURL はZennのベースURLです。空の場合はDefaultZennBaseURLを使用します。テスト用に差し替えられます。
+	BaseURL string
+	// HTTP はHTTPクライアントの設定です。
+	HTTP HTTPConfig
+}
+
+// ZennFetcher はZennのトピックRSSフィードから記事を取得するFetcherです。
+type ZennFetcher struct {
+	baseURL string
+	http    requester
+}
+
+// NewZennFetcher はZennFetcherの新しいインスタンスを作成します。
+func NewZennFetcher(cfg ZennConfig) *ZennFetcher {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultZennBaseURL
+	}
+	return &ZennFetcher{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		http:    newRequester(cfg.HTTP),
+	}
+}
+
+// Name はソース名を返します。
+func (f *ZennFetcher) Name() string {
+	return "zenn"
+}
+
+// Commit は記事の保存に成功した後に呼び出し、取得したレスポンスの検証子を保存します。
+func (f *ZennFetcher) Commit(ctx context.Context) error {
+	return f.http.commitValidators(ctx)
+}
+
+// Fetch は指定したタグ（トピック）のZenn記事を取得します。
+func (f *ZennFetcher) Fetch(ctx context.Context, tag string) ([]model.Article, error) {
+	if tag == "" {
+		// トピックが指定されていない場合は全体のフィードを使用
+		return f.fetchFeed(ctx, f.baseURL+"/feed", tag)
+	}
+	// Zennのトピック名は小文字（例: "Go" -> "go"）
+	topic := strings.ToLower(tag)
+	return f.fetchFeed(ctx, fmt.Sprintf("%s/topics/%s/feed", f.baseURL, url.PathEscape(topic)), tag)
+}
+
+func (f *ZennFetcher) fetchFeed(ctx context.Context, feedURL string, tag string) ([]model.Article, error) {
+	res, err := f.http.get(ctx, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Zenn feed: %w", err)
+	}
+	defer res.Body.Close()
+
+	// 前回の取得から変更がない
+	if res.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+
+	// ステータスコードを確認
+	if res.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("Zenn feed returned non-200 status: %d, body: %s", res.StatusCode, bodyBytes)
+	}
+
+	items, err := parseFeed(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Zenn feed: %w", err)
+	}
+	f.http.saveValidators(feedURL, res.Header)
+
+	return zennArticlesFromFeed(items, tag), nil
+}
+
+// フィードのエントリを内部モデルにマッピング
+func zennArticlesFromFeed(items []feedItem, tag string) []model.Article {
+	articles := make([]model.Article, 0, len(items))
+	for _, it := range items {
+		if it.Link == "" {
+			continue
+		}
+
+		// トピックフィードにはタグ情報が含まれないため、取得に使ったトピックとフィード内のカテゴリをタグとする
+		var tags []string
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+		for _, c := range it.Categories {
+			if !strings.EqualFold(c, tag) {
+				tags = append(tags, c)
 			}
 		}
-		articles = filteredArticles
+
+		// フィードの説明は本文の冒頭のみのため、抜粋にのみ使い語数は設定しない
+		excerpt, _ := excerptFromHTML(it.Description)
+
+		articles = append(articles, model.Article{
+			ID:          zennArticleID(it.Link),
+			Title:       it.Title,
+			URL:         it.Link,
+			Tags:        tags,
+			Likes:       0, // フィードにはいいね数が含まれない
+			PublishedAt: it.PublishedAt,
+			Source:      "Zenn",
+			Excerpt:     excerpt,
+			Author:      zennAuthor(it),
+		})
+	}
+	return articles
+}
+
+// 記事URL（https://zenn.dev/{user}/articles/{slug}）からドキュメントIDを生成する
+// FirestoreのドキュメントIDに "/" は使えないため、slug部分のみを使う
+func zennArticleID(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "zenn-" + url.PathEscape(link)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	slug := segments[len(segments)-1]
+	if slug == "" {
+		return "zenn-" + url.PathEscape(link)
 	}
+	return "zenn-" + slug
+}
 
-	return articles, nil
+// 記事URL（https://zenn.dev/{user}/articles/{slug}）のユーザー名とフィードの著者名から著者を作成する
+func zennAuthor(it feedItem) *model.Author {
+	u, err := url.Parse(it.Link)
+	if err != nil {
+		return nil
+	}
+	username := strings.Split(strings.Trim(u.Path, "/"), "/")[0]
+	if username == "" {
+		return nil
+	}
+	name := it.Author
+	if name == "" {
+		name = username
+	}
+	return &model.Author{
+		ID:         username,
+		Name:       name,
+		ProfileURL: u.Scheme + "://" + u.Host + "/" + username,
+	}
 }
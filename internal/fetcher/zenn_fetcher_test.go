@@ -0,0 +1,154 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+// パスごとにフィクスチャを返すテスト用のサーバー
+func newFixtureServer(t *testing.T, routes map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Errorf("failed to read fixture: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+type zennWant struct {
+	id, title, url, author string
+	tags                   []string
+	publishedAt            time.Time
+}
+
+func TestZennFetcherFetch(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    []zennWant
+	}{
+		{
+			name:    "RSS",
+			fixture: "testdata/zenn_topic_go.xml",
+			want: []zennWant{
+				{
+					id:          "zenn-go-context-guide",
+					title:       "Goのcontextを正しく使う",
+					url:         "https://zenn.dev/gopher/articles/go-context-guide",
+					author:      "gopher",
+					tags:        []string{"Go"},
+					publishedAt: time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC),
+				},
+				{
+					id:          "zenn-echo-rest-api",
+					title:       "Echoで作るREST API",
+					url:         "https://zenn.dev/echo_user/articles/echo-rest-api",
+					author:      "echo_user",
+					tags:        []string{"Go"},
+					publishedAt: time.Date(2024, 6, 30, 9, 30, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:    "Atom",
+			fixture: "testdata/zenn_topic_go.atom",
+			want: []zennWant{
+				{
+					id:          "zenn-go-generics-intro",
+					title:       "Goのジェネリクス入門",
+					url:         "https://zenn.dev/gopher/articles/go-generics-intro",
+					author:      "gopher",
+					tags:        []string{"Go", "Generics"},
+					publishedAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// トピック名は小文字でリクエストされる
+			srv := newFixtureServer(t, map[string]string{"/topics/go/feed": tt.fixture})
+			f := NewZennFetcher(ZennConfig{BaseURL: srv.URL})
+
+			articles, err := f.Fetch(context.Background(), "Go")
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if len(articles) != len(tt.want) {
+				t.Fatalf("Fetch() returned %d articles, want %d", len(articles), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := articles[i]
+				if got.ID != want.id || got.Title != want.title || got.URL != want.url {
+					t.Errorf("article %d = {%q %q %q}, want {%q %q %q}", i, got.ID, got.Title, got.URL, want.id, want.title, want.url)
+				}
+				if got.Source != "Zenn" {
+					t.Errorf("article %d Source = %q, want Zenn", i, got.Source)
+				}
+				// フィードには本文がないため、抜粋のみ設定し語数は設定しない
+				if got.Excerpt == "" || got.WordCount != 0 {
+					t.Errorf("article %d Excerpt = %q, WordCount = %d, want excerpt and 0", i, got.Excerpt, got.WordCount)
+				}
+				if !slices.Equal(got.Tags, want.tags) {
+					t.Errorf("article %d Tags = %v, want %v", i, got.Tags, want.tags)
+				}
+				if got.Author == nil || got.Author.ID != want.author || got.Author.ProfileURL != "https://zenn.dev/"+want.author {
+					t.Errorf("article %d Author = %+v, want ID %q", i, got.Author, want.author)
+				}
+				if !got.PublishedAt.Equal(want.publishedAt) {
+					t.Errorf("article %d PublishedAt = %v, want %v", i, got.PublishedAt, want.publishedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestZennFetcherNotModified(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		body, _ := os.ReadFile("testdata/zenn_topic_go.xml")
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	f := NewZennFetcher(ZennConfig{BaseURL: srv.URL, HTTP: HTTPConfig{Validators: NewMemoryValidatorStore()}})
+	if _, err := f.Fetch(context.Background(), "Go"); err != nil {
+		t.Fatalf("first Fetch() error = %v", err)
+	}
+	// 記事の保存を確定するまでは条件付きリクエストにしない
+	if articles, err := f.Fetch(context.Background(), "Go"); err != nil || len(articles) != 2 {
+		t.Fatalf("Fetch() before Commit = %d articles, %v, want 2 articles", len(articles), err)
+	}
+	if err := f.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if _, err := f.Fetch(context.Background(), "Go"); err != ErrNotModified {
+		t.Fatalf("Fetch() after Commit error = %v, want ErrNotModified", err)
+	}
+	if requests != 3 {
+		t.Errorf("server received %d requests, want 3", requests)
+	}
+}
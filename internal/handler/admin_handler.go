@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// SourceHealthService はソースの稼働状況を提供するサービス層へのインターフェースです。
+type SourceHealthService interface {
+	SourceHealth() []model.SourceHealth
+}
+
+// AdminHandler は管理用のリクエストを処理するハンドラーです。
+type AdminHandler struct {
+	service SourceHealthService
+}
+
+// NewAdminHandler はAdminHandlerの新しいインスタンスを作成します。
+func NewAdminHandler(service SourceHealthService) *AdminHandler {
+	return &AdminHandler{service: service}
+}
+
+// GetSourceHealth はソースごとの稼働状況（連続失敗回数、最終成功日時、一時停止中かなど）を返します。
+func (h *AdminHandler) GetSourceHealth(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.service.SourceHealth())
+}
@@ -1,18 +1,21 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 
 	"context"
 
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/langdetect"
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
 )
 
 // ArticleService は記事サービス層へのインターフェースです。
 type ArticleService interface {
-	GetPopularArticles(ctx context.Context, tag string) ([]model.Article, error)
+	GetPopularArticles(ctx context.Context, query model.ArticleQuery) (model.ArticlePage, error)
 	// 必要に応じて他のメソッドを追加
 }
 
@@ -28,24 +31,52 @@ func NewArticleHandler(service ArticleService) *ArticleHandler {
 
 // 記事一覧取得ハンドラー
 func (h *ArticleHandler) GetArticles(c echo.Context) error {
-	tag := c.QueryParam("tag")
-	// limitStr := c.QueryParam("limit") // 現在はlimitを使っていない
-	// limit := 15
-	// if limitStr != "" {
-	// 	if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 30 {
-	// 		limit = l
-	// 	}
-	// }
+	query := model.ArticleQuery{
+		Author: c.QueryParam("author"),
+		Lang:   c.QueryParam("lang"),
+	}
+	// タグは複数指定できる（例: ?tag=Go&tag=Docker&match=all）
+	for _, tag := range c.QueryParams()["tag"] {
+		if tag != "" {
+			query.Tags = append(query.Tags, tag)
+		}
+	}
+	if len(query.Tags) > model.MaxQueryTags {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("tagは%d個まで指定できます", model.MaxQueryTags)})
+	}
+	switch match := model.TagMatch(c.QueryParam("match")); match {
+	case "", model.TagMatchAny, model.TagMatchAll:
+		query.Match = match
+	default:
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "matchはanyまたはallを指定してください"})
+	}
+	if query.Lang != "" && !langdetect.Valid(query.Lang) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "langはja, en, otherのいずれかを指定してください"})
+	}
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 || l > model.MaxArticleLimit {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": fmt.Sprintf("limitは1から%dまでの整数を指定してください", model.MaxArticleLimit)})
+		}
+		query.Limit = l
+	}
+	if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+		cursor, err := model.DecodeArticleCursor(cursorStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "cursorが不正です"})
+		}
+		query.Cursor = cursor
+	}
 
 	ctx := c.Request().Context()
 
-	// サービス層を介して記事を取得
-	articles, err := h.service.GetPopularArticles(ctx, tag)
+	// サービス層を介して記事を取得（次のページはレスポンスのnextCursorをcursorに指定して取得する）
+	page, err := h.service.GetPopularArticles(ctx, query)
 	if err != nil {
 		// エラーハンドリングを適切に行う
 		c.Logger().Errorf("failed to get articles from service: %v", err)
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "記事の取得に失敗しました"})
 	}
 
-	return c.JSON(http.StatusOK, articles)
+	return c.JSON(http.StatusOK, page)
 }
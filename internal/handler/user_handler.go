@@ -7,6 +7,7 @@ import (
 
 	"context"
 
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/langdetect"
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/middleware"
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
 )
@@ -14,7 +15,8 @@ import (
 // UserService はユーザーサービス層へのインターフェースです。
 type UserService interface {
 	GetUser(ctx context.Context, userID string) (*model.User, error)
-	UpdateUserTags(ctx context.Context, userID string, tags []string) error
+	UpdateUserTags(ctx context.Context, userID string, tags []string) ([]string, error)
+	UpdateUserLang(ctx context.Context, userID string, lang string) error
 	// 必要に応じて他のメソッドを追加
 }
 
@@ -66,11 +68,43 @@ func (h *UserHandler) UpdateUserTags(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	// サービス層を介してユーザーのタグを更新
-	if err := h.service.UpdateUserTags(ctx, uid, req.Tags); err != nil {
+	// 保存されるタグは正規化されるため、保存後のタグを返す
+	tags, err := h.service.UpdateUserTags(ctx, uid, req.Tags)
+	if err != nil {
 		// エラーハンドリングを適切に行う
 		c.Logger().Errorf("failed to update user tags via service: %v", err)
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "タグ更新に失敗しました"})
 	}
 
-	return c.JSON(http.StatusOK, echo.Map{"tags": req.Tags})
+	return c.JSON(http.StatusOK, echo.Map{"tags": tags})
+}
+
+// ユーザーの優先言語更新ハンドラー
+func (h *UserHandler) UpdateUserLang(c echo.Context) error {
+	type reqBody struct {
+		Lang string `json:"lang"`
+	}
+	var req reqBody
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid request"})
+	}
+	// 空文字は優先言語の解除として扱う
+	if req.Lang != "" && req.Lang != langdetect.Japanese && req.Lang != langdetect.English {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "langはjaまたはenを指定してください"})
+	}
+
+	uid, ok := c.Get(middleware.ContextUIDKey).(string)
+	if !ok || uid == "" {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	ctx := c.Request().Context()
+
+	// サービス層を介してユーザーの優先言語を更新
+	if err := h.service.UpdateUserLang(ctx, uid, req.Lang); err != nil {
+		c.Logger().Errorf("failed to update user lang via service: %v", err)
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "言語設定の更新に失敗しました"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"lang": req.Lang})
 }
@@ -0,0 +1,59 @@
+// Package langdetect は文字種の割合から記事の言語を推定します。外部サービスは使用しません。
+package langdetect
+
+import "unicode"
+
+// 推定結果の言語コード
+const (
+	Japanese = "ja"
+	English  = "en"
+	Other    = "other"
+)
+
+const (
+	// 文字のうち、かなと漢字がこの割合以上の場合は日本語とみなす
+	japaneseRatio = 0.1
+	// 文字のうち、ラテン文字（ASCII）がこの割合以上の場合は英語とみなす
+	englishRatio = 0.8
+)
+
+// Valid はlangが推定結果として取りうる言語コードかどうかを返します。
+func Valid(lang string) bool {
+	switch lang {
+	case Japanese, English, Other:
+		return true
+	}
+	return false
+}
+
+// Detect はテキストの言語を "ja", "en", "other" のいずれかで返します。
+// かな・漢字・ラテン文字の割合のみで判定するため、英語以外のラテン文字の言語も "en" となる場合があります。
+func Detect(text string) string {
+	var kana, han, latin, letters int
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case r < unicode.MaxASCII && unicode.IsLetter(r):
+			latin++
+		case !unicode.IsLetter(r):
+			continue
+		}
+		letters++
+	}
+	if letters == 0 {
+		return Other
+	}
+
+	// 「Go入門」のように漢字とラテン文字のみのタイトルも多いため、かながなくても日本語とみなす
+	// （中国語も日本語と判定されるが、取得元は日本語のサイトが中心のため区別しない）
+	if float64(kana+han)/float64(letters) >= japaneseRatio {
+		return Japanese
+	}
+	if float64(latin)/float64(letters) >= englishRatio {
+		return English
+	}
+	return Other
+}
@@ -0,0 +1,32 @@
+package langdetect
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "日本語", text: "Goでテストを書く方法", want: Japanese},
+		{name: "英語", text: "How to write tests in Go", want: English},
+		{name: "かなとラテン文字", text: "Reactのカスタムフックを作ってみた", want: Japanese},
+		{name: "漢字とラテン文字", text: "Go入門", want: Japanese},
+		{name: "漢字とラテン文字（長いラテン文字）", text: "Rust非同期処理", want: Japanese},
+		{name: "漢字のみ", text: "非同期処理", want: Japanese},
+		{name: "空文字列", text: "", want: Other},
+		{name: "記号と数字のみ", text: "1.23 -> 4.56!", want: Other},
+		{name: "日本語の割合がちょうど下限", text: "abcdefghiあ", want: Japanese},
+		{name: "日本語の割合が下限未満", text: "abcdefghijあ", want: English},
+		{name: "英語の割合がちょうど下限", text: "abcdж", want: English},
+		{name: "英語の割合が下限未満", text: "abcж", want: Other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAdminToken は管理用APIのトークンを指定するヘッダーです。
+const HeaderAdminToken = "X-Admin-Token"
+
+// AdminToken は管理用APIのトークン認証ミドルウェアです。
+// tokenが空の場合は設定漏れで管理用APIが公開されないよう、すべてのリクエストを拒否します。
+func AdminToken(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if token == "" {
+				return c.JSON(http.StatusForbidden, echo.Map{"error": "admin api is disabled"})
+			}
+			given := c.Request().Header.Get(HeaderAdminToken)
+			if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid admin token"})
+			}
+			return next(c)
+		}
+	}
+}
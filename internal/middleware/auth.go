@@ -20,6 +20,10 @@ func FirebaseAuth(next echo.HandlerFunc) echo.HandlerFunc {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "missing or invalid token"})
 		}
 		tokenString := strings.TrimPrefix(header, "Bearer ")
+		// Firebaseを使わずに起動している場合（STORAGE=memory）は認証できない
+		if config.FirebaseApp == nil {
+			return c.JSON(http.StatusServiceUnavailable, echo.Map{"error": "authentication is not available"})
+		}
 		ctx := context.Background()
 		auth, err := config.FirebaseApp.Auth(ctx)
 		if err != nil {
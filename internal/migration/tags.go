@@ -0,0 +1,49 @@
+// Package migration はFirestoreの既存ドキュメントを変換する一回限りの移行処理を提供します。
+package migration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	firestore "cloud.google.com/go/firestore"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/tagnorm"
+)
+
+// Result は移行処理の結果です。
+type Result struct {
+	Scanned int // 読み込んだドキュメント数
+	Updated int // 更新した（dryRunの場合は更新対象の）ドキュメント数
+}
+
+// NormalizeTags は記事とユーザーのtagsフィールドを辞書で正規化します。
+// dryRunがtrueの場合は更新対象を数えるだけで書き込みは行いません。
+func NormalizeTags(ctx context.Context, client *firestore.Client, dict *tagnorm.Dictionary, dryRun bool) (map[string]Result, error) {
+	results := make(map[string]Result)
+	for _, collection := range []string{"articles", "users"} {
+		res, err := normalizeCollectionTags(ctx, client, collection, dict, dryRun)
+		results[collection] = res
+		if err != nil {
+			return results, fmt.Errorf("failed to normalize tags in %s: %w", collection, err)
+		}
+	}
+	return results, nil
+}
+
+func normalizeCollectionTags(ctx context.Context, client *firestore.Client, collection string, dict *tagnorm.Dictionary, dryRun bool) (Result, error) {
+	query := client.Collection(collection).Select("tags")
+	return updateDocuments(ctx, client, query, func(doc *firestore.DocumentSnapshot) []firestore.Update {
+		var data struct {
+			Tags []string `firestore:"tags"`
+		}
+		if err := doc.DataTo(&data); err != nil || len(data.Tags) == 0 {
+			return nil
+		}
+		normalized := dict.NormalizeAll(data.Tags)
+		if slices.Equal(normalized, data.Tags) {
+			return nil
+		}
+		return []firestore.Update{{Path: "tags", Value: normalized}}
+	}, dryRun)
+}
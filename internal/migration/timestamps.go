@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	firestore "cloud.google.com/go/firestore"
+)
+
+// timestampFields は文字列からタイムスタンプに変換する記事のフィールドです。
+var timestampFields = []string{"publishedAt", "fetchedAt"}
+
+// ConvertTimestamps は記事のpublishedAtとfetchedAtをRFC3339形式の文字列からFirestoreのタイムスタンプに変換します。
+// 空文字列やパースできない値、ゼロ値の日時（"0001-01-01T00:00:00Z"）はnullにします。
+// dryRunがtrueの場合は更新対象を数えるだけで書き込みは行いません。
+func ConvertTimestamps(ctx context.Context, client *firestore.Client, dryRun bool) (Result, error) {
+	query := client.Collection("articles").Select(timestampFields...)
+	return updateDocuments(ctx, client, query, func(doc *firestore.DocumentSnapshot) []firestore.Update {
+		data := doc.Data()
+		var updates []firestore.Update
+		for _, field := range timestampFields {
+			// 文字列のフィールドのみ変換する（変換済みのタイムスタンプ、null、未設定はそのまま）
+			s, ok := data[field].(string)
+			if !ok {
+				continue
+			}
+			updates = append(updates, firestore.Update{Path: field, Value: parseTimestamp(s)})
+		}
+		return updates
+	}, dryRun)
+}
+
+// RFC3339形式の文字列をタイムスタンプにする。日時が不明な場合はnilを返す
+func parseTimestamp(s string) interface{} {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil || t.IsZero() {
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,79 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// updateFunc はドキュメントに対する更新を返します。更新が不要な場合はnilを返します。
+type updateFunc func(doc *firestore.DocumentSnapshot) []firestore.Update
+
+// queryのドキュメントを読み込み、updateが返す更新を書き込む
+// dryRunがtrueの場合は更新対象を数えるだけで書き込みは行わない
+func updateDocuments(ctx context.Context, client *firestore.Client, query firestore.Query, update updateFunc, dryRun bool) (Result, error) {
+	var res Result
+
+	// BulkWriterは書き込みを自動的に分割・再試行する
+	var bw *firestore.BulkWriter
+	if !dryRun {
+		bw = client.BulkWriter(ctx)
+	}
+	var jobs []*firestore.BulkWriterJob
+
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			if bw != nil {
+				bw.End()
+			}
+			return res, fmt.Errorf("failed to read documents: %w", err)
+		}
+		res.Scanned++
+
+		updates := update(doc)
+		if len(updates) == 0 {
+			continue
+		}
+		res.Updated++
+		if dryRun {
+			continue
+		}
+
+		job, err := bw.Update(doc.Ref, updates)
+		if err != nil {
+			bw.End()
+			return res, fmt.Errorf("failed to enqueue update for %s: %w", doc.Ref.ID, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if bw == nil {
+		return res, nil
+	}
+	bw.End()
+
+	// 失敗した書き込みを数える
+	failed := 0
+	var firstErr error
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	res.Updated -= failed
+	if failed > 0 {
+		return res, fmt.Errorf("%d updates failed, first error: %w", failed, firstErr)
+	}
+	return res, nil
+}
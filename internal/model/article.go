@@ -1,12 +1,188 @@
 package model
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
 type Article struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	URL         string   `json:"url"`
-	Tags        []string `json:"tags"`
-	Likes       int      `json:"likes"`
-	PublishedAt string   `json:"publishedAt"`
-	Source      string   `json:"source"`
-	FetchedAt   string   `json:"fetchedAt"`
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Tags        []string  `json:"tags"`
+	Likes       int       `json:"likes"`
+	PublishedAt time.Time `json:"publishedAt"` // 記事の公開日時（不明な場合はゼロ値）
+	Source      string    `json:"source"`
+	FetchedAt   time.Time `json:"fetchedAt"`           // 記事を取得した日時
+	Lang        string    `json:"lang,omitempty"`      // 記事の言語（"ja", "en", "other"）
+	Excerpt     string    `json:"excerpt,omitempty"`   // 本文から作成したプレーンテキストの抜粋
+	WordCount   int       `json:"wordCount,omitempty"` // 本文の語数（日本語は文字数）。要約しか取得できないソースでは0
+	Author      *Author   `json:"author,omitempty"`
+
+	// 記事ページのOGPから取得した情報
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"` // og:image
+	Description  string `json:"description,omitempty"`  // og:description
+	SiteName     string `json:"siteName,omitempty"`     // og:site_name
+	// OGPCheckedAt は記事ページを取得してOGPを確認した日時です（ゼロ値は未確認）。OGPのないページを繰り返し取得しないために使います
+	OGPCheckedAt time.Time `json:"-"`
+}
+
+// MarshalJSON は日時をRFC3339形式の文字列で出力します。ゼロ値の日時は空文字列で出力します。
+func (a Article) MarshalJSON() ([]byte, error) {
+	type article Article // MarshalJSONを引き継がない型
+	return json.Marshal(struct {
+		article
+		PublishedAt string `json:"publishedAt"`
+		FetchedAt   string `json:"fetchedAt"`
+	}{
+		article:     article(a),
+		PublishedAt: formatTime(a.PublishedAt),
+		FetchedAt:   formatTime(a.FetchedAt),
+	})
+}
+
+// UnmarshalJSON はMarshalJSONの出力を読み込みます。日時はRFC3339形式の文字列で、空文字列はゼロ値として扱います。
+func (a *Article) UnmarshalJSON(data []byte) error {
+	type article Article // UnmarshalJSONを引き継がない型
+	aux := struct {
+		*article
+		PublishedAt string `json:"publishedAt"`
+		FetchedAt   string `json:"fetchedAt"`
+	}{article: (*article)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if a.PublishedAt, err = parseTime(aux.PublishedAt); err != nil {
+		return fmt.Errorf("invalid publishedAt: %w", err)
+	}
+	if a.FetchedAt, err = parseTime(aux.FetchedAt); err != nil {
+		return fmt.Errorf("invalid fetchedAt: %w", err)
+	}
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// DocumentID は記事を保存するドキュメントのIDです。IDがない場合はURLを使用します。
+func (a Article) DocumentID() string {
+	if a.ID == "" {
+		return a.URL
+	}
+	return a.ID
+}
+
+// Author は記事の著者です。
+type Author struct {
+	ID         string `json:"id"`                   // ソース上のユーザーID
+	Name       string `json:"name"`                 // 表示名
+	AvatarURL  string `json:"avatarUrl,omitempty"`  // プロフィール画像のURL
+	ProfileURL string `json:"profileUrl,omitempty"` // ソース上のプロフィールページのURL
+}
+
+// TagMatch は複数のタグを指定した場合の絞り込み方法です。
+type TagMatch string
+
+const (
+	// TagMatchAny はいずれかのタグを持つ記事に絞り込みます（デフォルト）。
+	TagMatchAny TagMatch = "any"
+	// TagMatchAll はすべてのタグを持つ記事に絞り込みます。
+	TagMatchAll TagMatch = "all"
+)
+
+// MaxQueryTags は記事一覧の絞り込みに指定できるタグ数の上限です。
+const MaxQueryTags = 10
+
+// ArticleQuery は記事一覧の取得条件です。
+type ArticleQuery struct {
+	Tags   []string // 指定したタグを持つ記事に絞り込む（空の場合は絞り込まない）
+	Match  TagMatch // Tagsが複数の場合の絞り込み方法（空の場合はTagMatchAny）
+	Author string   // 指定した著者IDの記事に絞り込む（空の場合は絞り込まない）
+	Lang   string   // 指定した言語（"ja", "en", "other"）の記事に絞り込む（空の場合は絞り込まない）
+
+	Limit  int            // 1ページの記事数（0の場合はDefaultArticleLimit）
+	Cursor *ArticleCursor // 前のページの最後の記事の位置（nilの場合は先頭から）
+}
+
+const (
+	// DefaultArticleLimit は記事一覧の1ページの記事数のデフォルト値です。
+	DefaultArticleLimit = 50
+	// MaxArticleLimit は記事一覧の1ページの記事数の上限です。
+	MaxArticleLimit = 100
+)
+
+// PageSize は1ページの記事数を返します。0以下の場合はデフォルト値、上限を超える場合は上限に揃えます。
+func (q ArticleQuery) PageSize() int {
+	switch {
+	case q.Limit <= 0:
+		return DefaultArticleLimit
+	case q.Limit > MaxArticleLimit:
+		return MaxArticleLimit
+	}
+	return q.Limit
+}
+
+// ArticlePage は記事一覧の1ページです。
+type ArticlePage struct {
+	Items []Article `json:"items"`
+	// NextCursor は次のページを取得するためのカーソルです。次のページがない場合は空です。
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// ArticleCursor は記事一覧の並び順（いいね数の降順、同数の場合はドキュメントIDの降順）での位置です。
+type ArticleCursor struct {
+	Likes int    `json:"l"`
+	ID    string `json:"i"` // ドキュメントID
+}
+
+// ErrInvalidCursor はカーソルの形式が正しくないことを表します。
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Encode はカーソルをクライアントに渡す不透明な文字列に変換します。
+func (c *ArticleCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeArticleCursor はEncodeで作成した文字列をカーソルに戻します。
+func DecodeArticleCursor(s string) (*ArticleCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c ArticleCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// After はいいね数とドキュメントIDがidの記事が、記事一覧の並び順でカーソルより後にあるかを返します。
+func (c *ArticleCursor) After(likes int, id string) bool {
+	if likes != c.Likes {
+		return likes < c.Likes
+	}
+	return id < c.ID
+}
+
+// SaveResult は記事の保存結果です。
+type SaveResult struct {
+	Written   int      `json:"written"`             // 書き込めた記事数
+	Failed    int      `json:"failed"`              // 書き込みに失敗した記事数
+	FailedIDs []string `json:"failedIds,omitempty"` // 書き込みに失敗した記事のドキュメントID
 }
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestArticleJSONRoundTrip(t *testing.T) {
+	tests := []Article{
+		{
+			ID:          "qiita-1",
+			Title:       "Go入門",
+			Tags:        []string{"Go"},
+			Likes:       10,
+			PublishedAt: time.Date(2024, 7, 1, 1, 0, 0, 0, time.UTC),
+			FetchedAt:   time.Date(2024, 7, 2, 3, 4, 5, 0, time.UTC),
+			Author:      &Author{ID: "gopher", Name: "Gopher"},
+		},
+		// 日時が不明な記事は空文字列で出力される
+		{ID: "hatena-1", Title: "日時なし"},
+	}
+	for _, want := range tests {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		var got Article
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", data, err)
+		}
+		if got.ID != want.ID || got.Title != want.Title || got.Likes != want.Likes ||
+			!got.PublishedAt.Equal(want.PublishedAt) || !got.FetchedAt.Equal(want.FetchedAt) {
+			t.Errorf("round trip of %s = %+v, want %+v", data, got, want)
+		}
+		if (got.Author == nil) != (want.Author == nil) || (got.Author != nil && *got.Author != *want.Author) {
+			t.Errorf("round trip Author = %+v, want %+v", got.Author, want.Author)
+		}
+	}
+}
+
+func TestArticleUnmarshalJSON(t *testing.T) {
+	var a Article
+	if err := json.Unmarshal([]byte(`{"id":"a","publishedAt":"2024-07-01T10:00:00+09:00","fetchedAt":""}`), &a); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !a.PublishedAt.Equal(time.Date(2024, 7, 1, 1, 0, 0, 0, time.UTC)) || !a.FetchedAt.IsZero() {
+		t.Errorf("dates = %v, %v", a.PublishedAt, a.FetchedAt)
+	}
+
+	// 日時の項目がない場合もゼロ値
+	a = Article{}
+	if err := json.Unmarshal([]byte(`{"id":"b"}`), &a); err != nil || !a.PublishedAt.IsZero() {
+		t.Errorf("Unmarshal() without dates = %v, %v", a.PublishedAt, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"c","publishedAt":"2024/07/01"}`), &a); err == nil {
+		t.Error("Unmarshal() error = nil, want error for invalid publishedAt")
+	}
+}
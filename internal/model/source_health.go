@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// SourceHealth は記事の取得元（ソース）の稼働状況です。
+type SourceHealth struct {
+	Source              string     `json:"source"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`     // 連続した取得失敗の回数（タグのうち最も多いもの）
+	LastSuccessAt       *time.Time `json:"lastSuccessAt,omitempty"` // 最後に取得に成功した日時
+	LastFailureAt       *time.Time `json:"lastFailureAt,omitempty"` // 最後に取得に失敗した日時
+	LastError           string     `json:"lastError,omitempty"`     // 最後の取得失敗のエラー
+	CircuitOpen         bool       `json:"circuitOpen"`             // いずれかのタグの取得を一時停止しているか
+	OpenUntil           *time.Time `json:"openUntil,omitempty"`     // 停止しているタグの取得をすべて再開する日時
+	OpenTags            []string   `json:"openTags,omitempty"`      // 取得を一時停止しているタグ
+}
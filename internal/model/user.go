@@ -5,4 +5,5 @@ type User struct {
 	Name  string   `json:"name"`
 	Email string   `json:"email"`
 	Tags  []string `json:"tags"`
+	Lang  string   `json:"lang,omitempty"` // 記事の表示で優先する言語（"ja", "en"）
 }
@@ -2,9 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	// "github.com/iwatsukayugaku/my-tech-articles-app/backend/config" // 直接Clientを受け取るため不要
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
 )
@@ -13,8 +18,10 @@ const articleCollection = "articles"
 
 // ArticleRepository は記事データへのアクセスを抽象化するインターフェースです。
 type ArticleRepository interface {
-	SaveArticles(ctx context.Context, articles []model.Article) error
-	GetArticlesByTag(ctx context.Context, tag string) ([]model.Article, error)
+	SaveArticles(ctx context.Context, articles []model.Article) (model.SaveResult, error)
+	GetArticles(ctx context.Context, query model.ArticleQuery) (model.ArticlePage, error)
+	// GetArticlesByIDs はドキュメントIDを指定して記事を取得します。見つからないIDは結果に含めません。
+	GetArticlesByIDs(ctx context.Context, ids []string) (map[string]model.Article, error)
 	// 必要に応じて他のメソッドを追加
 }
 
@@ -28,63 +35,247 @@ func NewArticleRepository(client *firestore.Client) ArticleRepository {
 	return &firestoreArticleRepository{client: client}
 }
 
+const (
+	// maxBatchWrites は1回のバッチで書き込めるドキュメント数の上限です（Firestoreの制限）。
+	maxBatchWrites = 500
+	// batchCommitAttempts はバッチの書き込みに失敗した場合に試行する最大回数です。
+	batchCommitAttempts = 3
+	// batchRetryBaseDelay はバッチの再試行までの待ち時間の初期値です。再試行ごとに倍にします。
+	batchRetryBaseDelay = time.Second
+)
+
 // Firestoreに記事をキャッシュ保存
-func (r *firestoreArticleRepository) SaveArticles(ctx context.Context, articles []model.Article) error {
-	batch := r.client.Batch()
-	for _, a := range articles {
-		// ドキュメントIDとして記事のIDまたはURLを使用
-		docID := a.ID
-		if docID == "" {
-			docID = a.URL // IDがない場合はURLを使用
-		}
-		ref := r.client.Collection(articleCollection).Doc(docID)
-
-		// model.Article構造体をmap[string]interface{}に変換してからSetに渡す
-		data := map[string]interface{}{
-			"id":          a.ID,
-			"title":       a.Title,
-			"url":         a.URL,
-			"tags":        a.Tags,
-			"likes":       a.Likes,
-			"publishedAt": a.PublishedAt,
-			"source":      a.Source,
-			// "fetchedAt": time.Now().Format(time.RFC3339), // 記事取得日時を追加する場合はここで設定
+// 書き込みは500件ごとのバッチに分割し、失敗したバッチは再試行します。
+// 再試行しても失敗したバッチがあっても残りのバッチの書き込みは続け、書き込めた件数と失敗した件数を返します。
+func (r *firestoreArticleRepository) SaveArticles(ctx context.Context, articles []model.Article) (model.SaveResult, error) {
+	var result model.SaveResult
+	var errs []error
+	for start := 0; start < len(articles); start += maxBatchWrites {
+		chunk := articles[start:min(start+maxBatchWrites, len(articles))]
+		if err := r.commitWithRetry(ctx, chunk); err != nil {
+			result.Failed += len(chunk)
+			for _, a := range chunk {
+				result.FailedIDs = append(result.FailedIDs, a.DocumentID())
+			}
+			errs = append(errs, fmt.Errorf("articles %d-%d: %w", start, start+len(chunk)-1, err))
+			continue
 		}
+		result.Written += len(chunk)
+	}
+	if len(errs) > 0 {
+		return result, fmt.Errorf("failed to commit %d of %d articles: %w", result.Failed, len(articles), errors.Join(errs...))
+	}
+	return result, nil
+}
 
-		batch.Set(ref, data, firestore.MergeAll)
+// 1つのバッチを書き込む。一時的なエラーの場合は待ち時間を倍にしながら再試行する
+func (r *firestoreArticleRepository) commitWithRetry(ctx context.Context, articles []model.Article) error {
+	delay := batchRetryBaseDelay
+	var err error
+	for attempt := 1; attempt <= batchCommitAttempts; attempt++ {
+		batch := r.client.Batch()
+		for _, a := range articles {
+			ref := r.client.Collection(articleCollection).Doc(a.DocumentID())
+			batch.Set(ref, articleData(a), firestore.MergeAll)
+		}
+		if _, err = batch.Commit(ctx); err == nil {
+			return nil
+		}
+		if attempt == batchCommitAttempts || !isRetryable(err) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to commit articles batch: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
 	}
+	return fmt.Errorf("failed to commit articles batch: %w", err)
+}
 
-	// バッチが大きすぎる場合に分割してコミットする処理を追加するべきだが、簡易実装として省略
+// model.Article構造体をmap[string]interface{}に変換してからSetに渡す
+func articleData(a model.Article) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":          a.ID,
+		"title":       a.Title,
+		"url":         a.URL,
+		"tags":        a.Tags,
+		"likes":       a.Likes,
+		"publishedAt": timestampOrNil(a.PublishedAt),
+		"source":      a.Source,
+		"lang":        a.Lang,
+		"excerpt":     a.Excerpt,
+		"wordCount":   a.WordCount,
+		"fetchedAt":   timestampOrNil(a.FetchedAt),
+	}
+	if a.Author != nil {
+		data["author"] = map[string]interface{}{
+			"id":         a.Author.ID,
+			"name":       a.Author.Name,
+			"avatarUrl":  a.Author.AvatarURL,
+			"profileUrl": a.Author.ProfileURL,
+		}
+	}
+	// OGP情報は取得できなかった場合に以前の値を消さないよう、取得できたものだけ保存する
+	if a.ThumbnailURL != "" {
+		data["thumbnailUrl"] = a.ThumbnailURL
+	}
+	if a.Description != "" {
+		data["description"] = a.Description
+	}
+	if a.SiteName != "" {
+		data["siteName"] = a.SiteName
+	}
+	if !a.OGPCheckedAt.IsZero() {
+		data["ogpCheckedAt"] = a.OGPCheckedAt
+	}
+	return data
+}
 
-	_, err := batch.Commit(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to commit articles batch: %w", err)
+// 日時はFirestoreのタイムスタンプとして保存する。不明な日時（ゼロ値）は範囲検索や並び替えに含めないようnullにする
+func timestampOrNil(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
 	}
+	return t
+}
 
-	return nil
+// 再試行すれば成功する可能性のあるエラーか
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal:
+		return true
+	}
+	return false
 }
 
-// Firestoreから記事キャッシュを取得（タグでフィルタ）
-func (r *firestoreArticleRepository) GetArticlesByTag(ctx context.Context, tag string) ([]model.Article, error) {
-	q := r.client.Collection(articleCollection).OrderBy("likes", firestore.Desc).Limit(50) // 例として最大50件取得
-	if tag != "" {
+// Firestoreから記事キャッシュを取得（タグ・著者・言語でフィルタ。複数のタグはいずれかを持つ記事）
+// いいね数の降順、同数の場合はドキュメントIDの降順に並べ、カーソルの次の記事から1ページ分を返す
+// 絞り込み条件といいね数の並び替えを組み合わせるため、Firestoreの複合インデックスが必要
+// （ドキュメントIDはインデックスに暗黙的に含まれる向きと同じ降順で並べるため、追加のインデックスは不要）
+func (r *firestoreArticleRepository) GetArticles(ctx context.Context, query model.ArticleQuery) (model.ArticlePage, error) {
+	limit := query.PageSize()
+	// 次のページがあるかを判定するため1件多く取得する
+	q := r.client.Collection(articleCollection).
+		OrderBy("likes", firestore.Desc).
+		OrderBy(firestore.DocumentID, firestore.Desc).
+		Limit(limit + 1)
+	switch {
+	case len(query.Tags) == 1:
 		// タグによる絞り込み。tagsフィールドがstring[]なのでarray-containsを使用
-		q = q.Where("tags", "array-contains", tag)
+		q = q.Where("tags", "array-contains", query.Tags[0])
+	case len(query.Tags) > 1:
+		// いずれかのタグを持つ記事に絞り込む（すべてのタグを持つ記事への絞り込みはサービス層で行う）
+		q = q.Where("tags", "array-contains-any", query.Tags)
+	}
+	if query.Author != "" {
+		q = q.Where("author.id", "==", query.Author)
+	}
+	if query.Lang != "" {
+		q = q.Where("lang", "==", query.Lang)
+	}
+	if query.Cursor != nil {
+		q = q.StartAfter(query.Cursor.Likes, query.Cursor.ID)
 	}
 	docs, err := q.Documents(ctx).GetAll()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get documents from firestore: %w", err)
+		return model.ArticlePage{}, fmt.Errorf("failed to get documents from firestore: %w", err)
+	}
+
+	page := model.ArticlePage{Items: []model.Article{}}
+	hasMore := len(docs) > limit
+	if hasMore {
+		docs = docs[:limit]
 	}
-	var articles []model.Article
 	for _, doc := range docs {
-		var a model.Article
-		// PublishedAtがtime.Time型としてFirestoreに保存されている場合、ここで変換が必要になる可能性
-		// model.ArticleのPublishedAtをstring型にしているので、Firestoreへの保存時に適切に変換されている前提
-		if err := doc.DataTo(&a); err == nil {
-			articles = append(articles, a)
+		a, err := articleFromDoc(doc)
+		if err != nil {
+			log.Printf("skipping article %s: %v", doc.Ref.ID, err)
+			continue
+		}
+		page.Items = append(page.Items, a)
+	}
+	if hasMore {
+		// 変換できなかった記事も含めて、このページで読んだ最後のドキュメントの次から続ける
+		last := docs[len(docs)-1]
+		likes, _ := last.DataAt("likes")
+		page.NextCursor = (&model.ArticleCursor{Likes: toInt(likes), ID: last.Ref.ID}).Encode()
+	}
+	return page, nil
+}
+
+// FirestoreからドキュメントIDを指定して記事を取得（存在しないドキュメントは結果に含めない）
+func (r *firestoreArticleRepository) GetArticlesByIDs(ctx context.Context, ids []string) (map[string]model.Article, error) {
+	found := make(map[string]model.Article, len(ids))
+	col := r.client.Collection(articleCollection)
+	for start := 0; start < len(ids); start += maxBatchWrites {
+		chunk := ids[start:min(start+maxBatchWrites, len(ids))]
+		refs := make([]*firestore.DocumentRef, len(chunk))
+		for i, id := range chunk {
+			refs[i] = col.Doc(id)
 		}
+		docs, err := r.client.GetAll(ctx, refs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get documents from firestore: %w", err)
+		}
+		for _, doc := range docs {
+			if !doc.Exists() {
+				continue
+			}
+			a, err := articleFromDoc(doc)
+			if err != nil {
+				log.Printf("skipping article %s: %v", doc.Ref.ID, err)
+				continue
+			}
+			found[doc.Ref.ID] = a
+		}
+	}
+	return found, nil
+}
+
+// firestoreArticle はFirestoreのドキュメントから記事を読み込むための型です。
+// 日時はタイムスタンプで保存しますが、移行前のドキュメントはRFC3339形式の文字列のため、どちらも読み込めるようにします。
+type firestoreArticle struct {
+	model.Article
+	PublishedAt interface{} `firestore:"publishedAt"`
+	FetchedAt   interface{} `firestore:"fetchedAt"`
+}
+
+// ドキュメントを記事に変換する
+func articleFromDoc(doc *firestore.DocumentSnapshot) (model.Article, error) {
+	var fa firestoreArticle
+	if err := doc.DataTo(&fa); err != nil {
+		return model.Article{}, fmt.Errorf("failed to map firestore data to article: %w", err)
+	}
+	a := fa.Article
+	a.PublishedAt = firestoreTime(fa.PublishedAt)
+	a.FetchedAt = firestoreTime(fa.FetchedAt)
+	return a, nil
+}
+
+// Firestoreの日時（タイムスタンプまたはRFC3339形式の文字列）をtime.Timeに変換する。不明な日時はゼロ値にする
+func firestoreTime(v interface{}) time.Time {
+	switch t := v.(type) {
+	case time.Time:
+		return t
+	case string:
+		if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+// Firestoreの数値（int64またはfloat64）をintに変換する
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
 	}
-	return articles, nil
+	return 0
 }
 
 // TODO: User関連のリポジトリ関数（GetUser, UpdateUserTagsなど）もこのファイルにまとめるか、別途user_repository.goに実装する
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFirestoreTime(t *testing.T) {
+	want := time.Date(2024, 7, 1, 1, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		v    interface{}
+		want time.Time
+	}{
+		{name: "タイムスタンプ", v: want, want: want},
+		{name: "RFC3339形式の文字列", v: "2024-07-01T10:00:00+09:00", want: want},
+		{name: "空文字列", v: "", want: time.Time{}},
+		{name: "パースできない文字列", v: "2024/07/01", want: time.Time{}},
+		{name: "null", v: nil, want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firestoreTime(tt.v); !got.Equal(tt.want) {
+				t.Errorf("firestoreTime(%v) = %v, want %v", tt.v, got, tt.want)
+			}
+		})
+	}
+}
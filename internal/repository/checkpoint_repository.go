@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const checkpointCollection = "fetch_checkpoints"
+
+// CheckpointRepository はソース・タグごとの前回の取得日時へのアクセスを抽象化するインターフェースです。
+type CheckpointRepository interface {
+	LastFetched(ctx context.Context, source, tag string) (time.Time, bool, error)
+	SetLastFetched(ctx context.Context, source, tag string, t time.Time) error
+}
+
+// firestoreCheckpointRepository はFirestoreをデータストアとして使用するCheckpointRepositoryの実装です。
+type firestoreCheckpointRepository struct {
+	client *firestore.Client
+}
+
+// NewCheckpointRepository はfirestoreCheckpointRepositoryの新しいインスタンスを作成します。
+func NewCheckpointRepository(client *firestore.Client) CheckpointRepository {
+	return &firestoreCheckpointRepository{client: client}
+}
+
+// ドキュメントIDは "{source}_{tag}"。タグに "/" が含まれてもよいようにエスケープする
+func checkpointDocID(source, tag string) string {
+	return url.PathEscape(source + "_" + tag)
+}
+
+// Firestoreから前回の取得日時を取得
+func (r *firestoreCheckpointRepository) LastFetched(ctx context.Context, source, tag string) (time.Time, bool, error) {
+	dsnap, err := r.client.Collection(checkpointCollection).Doc(checkpointDocID(source, tag)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, fmt.Errorf("failed to get checkpoint from firestore: %w", err)
+	}
+	var data struct {
+		LastFetchedAt time.Time `firestore:"lastFetchedAt"`
+	}
+	if err := dsnap.DataTo(&data); err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to map firestore data to checkpoint: %w", err)
+	}
+	return data.LastFetchedAt, true, nil
+}
+
+// Firestoreに取得日時を保存
+func (r *firestoreCheckpointRepository) SetLastFetched(ctx context.Context, source, tag string, t time.Time) error {
+	_, err := r.client.Collection(checkpointCollection).Doc(checkpointDocID(source, tag)).Set(ctx, map[string]interface{}{
+		"source":        source,
+		"tag":           tag,
+		"lastFetchedAt": t,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint to firestore: %w", err)
+	}
+	return nil
+}
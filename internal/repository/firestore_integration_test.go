@@ -0,0 +1,129 @@
+//go:build integration
+
+// Firestoreエミュレーターを使う結合テストです。
+// FIRESTORE_EMULATOR_HOSTを設定し、-tags integration を指定して実行します。
+//
+//	firebase emulators:start --only firestore
+//	FIRESTORE_EMULATOR_HOST=localhost:8080 go test -tags integration ./internal/repository/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	firestore "cloud.google.com/go/firestore"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// エミュレーター専用のプロジェクトID（"demo-" で始まるIDは本番のリソースにアクセスしない）
+const integrationProjectID = "demo-techee-test"
+
+// エミュレーターに接続したFirestoreクライアントを作成する。テストの前後にすべてのドキュメントを削除する
+func newEmulatorClient(t *testing.T) *firestore.Client {
+	t.Helper()
+	host := os.Getenv("FIRESTORE_EMULATOR_HOST")
+	if host == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST is not set")
+	}
+	clearEmulator(t, host)
+	client, err := firestore.NewClient(context.Background(), integrationProjectID)
+	if err != nil {
+		t.Fatalf("failed to create firestore client: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Close()
+		clearEmulator(t, host)
+	})
+	return client
+}
+
+// エミュレーターのデータを削除する
+func clearEmulator(t *testing.T, host string) {
+	t.Helper()
+	url := fmt.Sprintf("http://%s/emulator/v1/projects/%s/databases/(default)/documents", host, integrationProjectID)
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to clear firestore emulator: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("failed to clear firestore emulator: status %d", res.StatusCode)
+	}
+}
+
+func TestFirestoreArticleRepository(t *testing.T) {
+	testArticleRepository(t, func(t *testing.T) ArticleRepository {
+		return NewArticleRepository(newEmulatorClient(t))
+	})
+}
+
+func TestFirestoreUserRepository(t *testing.T) {
+	testUserRepository(t, NewUserRepository(newEmulatorClient(t)))
+}
+
+func TestFirestoreGetArticlesWithStringDates(t *testing.T) {
+	ctx := context.Background()
+	client := newEmulatorClient(t)
+	repo := NewArticleRepository(client)
+
+	// タイムスタンプへの移行前のドキュメント（日時がRFC3339形式の文字列）
+	if _, err := client.Collection(articleCollection).Doc("legacy").Set(ctx, map[string]interface{}{
+		"id":          "legacy",
+		"title":       "移行前の記事",
+		"likes":       1,
+		"publishedAt": "2024-07-01T10:00:00+09:00",
+		"fetchedAt":   "",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := repo.GetArticles(ctx, model.ArticleQuery{})
+	if err != nil {
+		t.Fatalf("GetArticles() error = %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Fatalf("GetArticles() returned %d articles, want 1", len(page.Items))
+	}
+	a := page.Items[0]
+	if !a.PublishedAt.Equal(time.Date(2024, 7, 1, 1, 0, 0, 0, time.UTC)) || !a.FetchedAt.IsZero() {
+		t.Errorf("dates = %v, %v, want 2024-07-01T01:00:00Z and zero", a.PublishedAt, a.FetchedAt)
+	}
+}
+
+func TestFirestoreSaveArticlesInChunks(t *testing.T) {
+	ctx := context.Background()
+	client := newEmulatorClient(t)
+	repo := NewArticleRepository(client)
+
+	// 1回のバッチの上限（500件）を超える件数
+	const n = 2*maxBatchWrites + 1
+	articles := make([]model.Article, n)
+	for i := range articles {
+		articles[i] = model.Article{ID: fmt.Sprintf("bulk-%04d", i), Title: "bulk", Likes: i}
+	}
+	result, err := repo.SaveArticles(ctx, articles)
+	if err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+	if result.Written != n || result.Failed != 0 {
+		t.Errorf("SaveArticles() = %+v, want %d written", result, n)
+	}
+
+	docs, err := client.Collection(articleCollection).Documents(ctx).GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != n {
+		t.Errorf("stored %d documents, want %d", len(docs), n)
+	}
+}
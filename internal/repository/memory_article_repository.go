@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// memoryArticleRepository はメモリ上に記事を保存するArticleRepositoryの実装です。
+// ローカルでの実行やテストのためのもので、Firestoreの実装と同じ並び順・件数・絞り込みで記事を返します。
+type memoryArticleRepository struct {
+	mu       sync.RWMutex
+	articles map[string]model.Article
+}
+
+// NewMemoryArticleRepository はmemoryArticleRepositoryの新しいインスタンスを作成します。
+func NewMemoryArticleRepository() ArticleRepository {
+	return &memoryArticleRepository{articles: make(map[string]model.Article)}
+}
+
+// メモリに記事を保存
+// FirestoreのMergeAllと同様に、著者やOGP情報がない記事で以前の値を消さない
+func (r *memoryArticleRepository) SaveArticles(ctx context.Context, articles []model.Article) (model.SaveResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range articles {
+		id := a.DocumentID()
+		a = cloneArticle(a)
+		if prev, ok := r.articles[id]; ok {
+			if a.Author == nil {
+				a.Author = prev.Author
+			}
+			if a.ThumbnailURL == "" {
+				a.ThumbnailURL = prev.ThumbnailURL
+			}
+			if a.Description == "" {
+				a.Description = prev.Description
+			}
+			if a.SiteName == "" {
+				a.SiteName = prev.SiteName
+			}
+			if a.OGPCheckedAt.IsZero() {
+				a.OGPCheckedAt = prev.OGPCheckedAt
+			}
+		}
+		r.articles[id] = a
+	}
+	return model.SaveResult{Written: len(articles)}, nil
+}
+
+// メモリから記事を取得（タグ・著者・言語でフィルタ。複数のタグはいずれかを持つ記事）
+// Firestoreと同様にいいね数の降順（同数の場合はドキュメントIDの降順）に並べ、カーソルの次の記事から1ページ分を返す
+func (r *memoryArticleRepository) GetArticles(ctx context.Context, query model.ArticleQuery) (model.ArticlePage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.articles))
+	for id, a := range r.articles {
+		if len(query.Tags) > 0 && !slices.ContainsFunc(query.Tags, func(tag string) bool { return slices.Contains(a.Tags, tag) }) {
+			continue
+		}
+		if query.Author != "" && (a.Author == nil || a.Author.ID != query.Author) {
+			continue
+		}
+		if query.Lang != "" && a.Lang != query.Lang {
+			continue
+		}
+		if query.Cursor != nil && !query.Cursor.After(a.Likes, id) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		li, lj := r.articles[ids[i]].Likes, r.articles[ids[j]].Likes
+		if li != lj {
+			return li > lj
+		}
+		return ids[i] > ids[j]
+	})
+
+	page := model.ArticlePage{Items: []model.Article{}}
+	if limit := query.PageSize(); len(ids) > limit {
+		ids = ids[:limit]
+		last := ids[len(ids)-1]
+		page.NextCursor = (&model.ArticleCursor{Likes: r.articles[last].Likes, ID: last}).Encode()
+	}
+	for _, id := range ids {
+		page.Items = append(page.Items, cloneArticle(r.articles[id]))
+	}
+	return page, nil
+}
+
+// メモリからドキュメントIDを指定して記事を取得
+func (r *memoryArticleRepository) GetArticlesByIDs(ctx context.Context, ids []string) (map[string]model.Article, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	found := make(map[string]model.Article, len(ids))
+	for _, id := range ids {
+		if a, ok := r.articles[id]; ok {
+			found[id] = cloneArticle(a)
+		}
+	}
+	return found, nil
+}
+
+// 保存した記事が呼び出し元から変更されないようにスライスとポインタをコピーする
+func cloneArticle(a model.Article) model.Article {
+	a.Tags = slices.Clone(a.Tags)
+	if a.Author != nil {
+		author := *a.Author
+		a.Author = &author
+	}
+	return a
+}
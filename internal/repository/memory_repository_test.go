@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+func TestMemoryArticleRepository(t *testing.T) {
+	testArticleRepository(t, func(t *testing.T) ArticleRepository {
+		return NewMemoryArticleRepository()
+	})
+}
+
+func TestMemoryUserRepository(t *testing.T) {
+	testUserRepository(t, NewMemoryUserRepository())
+}
+
+func TestMemoryArticleRepositoryCopiesArticles(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryArticleRepository()
+	articles := []model.Article{{ID: "a", Tags: []string{"Go"}, Author: &model.Author{ID: "gopher"}}}
+	if _, err := repo.SaveArticles(ctx, articles); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+
+	// 保存後・取得後に呼び出し元で変更しても、保存した記事は変わらない
+	articles[0].Tags[0] = "Rust"
+	articles[0].Author.ID = "ferris"
+	page, err := repo.GetArticles(ctx, model.ArticleQuery{})
+	if err != nil {
+		t.Fatalf("GetArticles() error = %v", err)
+	}
+	page.Items[0].Tags[0] = "Python"
+
+	got, err := repo.GetArticlesByIDs(ctx, []string{"a"})
+	if err != nil {
+		t.Fatalf("GetArticlesByIDs() error = %v", err)
+	}
+	if a := got["a"]; a.Tags[0] != "Go" || a.Author.ID != "gopher" {
+		t.Errorf("stored article = {%v %q}, want {[Go] gopher}", a.Tags, a.Author.ID)
+	}
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// memoryUserRepository はメモリ上にユーザーを保存するUserRepositoryの実装です。
+type memoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[string]model.User
+}
+
+// NewMemoryUserRepository はmemoryUserRepositoryの新しいインスタンスを作成します。
+func NewMemoryUserRepository() UserRepository {
+	return &memoryUserRepository{users: make(map[string]model.User)}
+}
+
+// メモリからユーザー情報を取得（存在しない場合はnilユーザーを返す）
+func (r *memoryUserRepository) GetUser(ctx context.Context, userID string) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, nil
+	}
+	user.Tags = slices.Clone(user.Tags)
+	return &user, nil
+}
+
+// メモリでユーザーのタグを更新（ユーザーが存在しない場合は作成）
+func (r *memoryUserRepository) UpdateUserTags(ctx context.Context, userID string, tags []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user := r.users[userID]
+	user.ID = userID
+	user.Tags = slices.Clone(tags)
+	r.users[userID] = user
+	return nil
+}
+
+// メモリでユーザーの優先言語を更新（ユーザーが存在しない場合は作成）
+func (r *memoryUserRepository) UpdateUserLang(ctx context.Context, userID string, lang string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user := r.users[userID]
+	user.ID = userID
+	user.Lang = lang
+	r.users[userID] = user
+	return nil
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// リポジトリの実装（メモリ・SQL・Firestore）に共通する振る舞いのテストです。
+// 各実装のテストから、空のリポジトリを作成する関数を渡して実行します。
+
+func articleIDs(articles []model.Article) []string {
+	ids := make([]string, len(articles))
+	for i, a := range articles {
+		ids[i] = a.DocumentID()
+	}
+	return ids
+}
+
+func testArticleRepository(t *testing.T, newRepo func(t *testing.T) ArticleRepository) {
+	t.Run("GetArticles", func(t *testing.T) {
+		testGetArticles(t, newRepo(t))
+	})
+	t.Run("SaveArticlesKeepsAuthorAndOGP", func(t *testing.T) {
+		testSaveArticlesKeepsAuthorAndOGP(t, newRepo(t))
+	})
+	t.Run("SaveArticlesReplacesTags", func(t *testing.T) {
+		testSaveArticlesReplacesTags(t, newRepo(t))
+	})
+	t.Run("GetArticlesByIDs", func(t *testing.T) {
+		testGetArticlesByIDs(t, newRepo(t))
+	})
+}
+
+func testGetArticles(t *testing.T, repo ArticleRepository) {
+	ctx := context.Background()
+	gopher := &model.Author{ID: "gopher", Name: "Gopher"}
+	if _, err := repo.SaveArticles(ctx, []model.Article{
+		{ID: "a", Title: "A", Likes: 30, Tags: []string{"Go"}, Lang: "ja", Author: gopher},
+		{ID: "b", Title: "B", Likes: 20, Tags: []string{"Go", "Docker"}, Lang: "en"},
+		{ID: "c", Title: "C", Likes: 20, Tags: []string{"Rust"}, Lang: "ja", Author: gopher},
+		{ID: "d", Title: "D", Likes: 10, Tags: []string{"Docker"}, Lang: "ja"},
+		{ID: "e", Title: "E", Likes: 5, Tags: []string{"Python"}, Lang: "en"},
+	}); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query model.ArticleQuery
+		want  []string
+	}{
+		// いいね数の降順、同数の場合はドキュメントIDの降順
+		{name: "並び順", query: model.ArticleQuery{}, want: []string{"a", "c", "b", "d", "e"}},
+		{name: "タグ", query: model.ArticleQuery{Tags: []string{"Go"}}, want: []string{"a", "b"}},
+		{name: "いずれかのタグ", query: model.ArticleQuery{Tags: []string{"Rust", "Docker"}}, want: []string{"c", "b", "d"}},
+		{name: "該当するタグなし", query: model.ArticleQuery{Tags: []string{"Elixir"}}, want: []string{}},
+		{name: "著者", query: model.ArticleQuery{Author: "gopher"}, want: []string{"a", "c"}},
+		{name: "言語", query: model.ArticleQuery{Lang: "en"}, want: []string{"b", "e"}},
+		{name: "言語とタグ", query: model.ArticleQuery{Lang: "ja", Tags: []string{"Docker"}}, want: []string{"d"}},
+		{name: "カーソルの次から", query: model.ArticleQuery{Cursor: &model.ArticleCursor{Likes: 20, ID: "c"}}, want: []string{"b", "d", "e"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.GetArticles(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("GetArticles() error = %v", err)
+			}
+			if got := articleIDs(page.Items); !slices.Equal(got, tt.want) {
+				t.Errorf("GetArticles() = %v, want %v", got, tt.want)
+			}
+			if page.NextCursor != "" {
+				t.Errorf("NextCursor = %q, want empty", page.NextCursor)
+			}
+		})
+	}
+
+	t.Run("カーソルによるページ分割", func(t *testing.T) {
+		var got []string
+		query := model.ArticleQuery{Limit: 2}
+		for pages := 0; ; pages++ {
+			if pages > 5 {
+				t.Fatal("too many pages")
+			}
+			page, err := repo.GetArticles(ctx, query)
+			if err != nil {
+				t.Fatalf("GetArticles() error = %v", err)
+			}
+			got = append(got, articleIDs(page.Items)...)
+			if page.NextCursor == "" {
+				break
+			}
+			cursor, err := model.DecodeArticleCursor(page.NextCursor)
+			if err != nil {
+				t.Fatalf("DecodeArticleCursor() error = %v", err)
+			}
+			query.Cursor = cursor
+		}
+		if want := []string{"a", "c", "b", "d", "e"}; !slices.Equal(got, want) {
+			t.Errorf("paged articles = %v, want %v", got, want)
+		}
+	})
+}
+
+func testSaveArticlesKeepsAuthorAndOGP(t *testing.T, repo ArticleRepository) {
+	ctx := context.Background()
+	first := model.Article{
+		ID:           "qiita-1",
+		Title:        "Go入門",
+		URL:          "https://qiita.com/gopher/items/1",
+		Tags:         []string{"Go"},
+		Likes:        10,
+		PublishedAt:  time.Date(2024, 7, 1, 1, 0, 0, 0, time.UTC),
+		Author:       &model.Author{ID: "gopher", Name: "Gopher", ProfileURL: "https://qiita.com/gopher"},
+		ThumbnailURL: "https://qiita.com/ogp.png",
+		Description:  "Goの入門記事",
+		SiteName:     "Qiita",
+		OGPCheckedAt: time.Date(2024, 7, 2, 3, 0, 0, 0, time.UTC),
+	}
+	if _, err := repo.SaveArticles(ctx, []model.Article{first}); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+
+	// 著者・OGP情報のない再取得結果で上書きしても、以前の値は残る
+	second := first
+	second.Title = "Go入門（改訂版）"
+	second.Likes = 20
+	second.Author = nil
+	second.ThumbnailURL, second.Description, second.SiteName = "", "", ""
+	second.OGPCheckedAt = time.Time{}
+	if _, err := repo.SaveArticles(ctx, []model.Article{second}); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+
+	got, err := repo.GetArticlesByIDs(ctx, []string{"qiita-1"})
+	if err != nil {
+		t.Fatalf("GetArticlesByIDs() error = %v", err)
+	}
+	a, ok := got["qiita-1"]
+	if !ok {
+		t.Fatal("saved article not found")
+	}
+	if a.Title != second.Title || a.Likes != 20 {
+		t.Errorf("article = {%q %d}, want {%q 20}", a.Title, a.Likes, second.Title)
+	}
+	if a.Author == nil || a.Author.ID != "gopher" || a.Author.ProfileURL != "https://qiita.com/gopher" {
+		t.Errorf("Author = %+v, want the first author", a.Author)
+	}
+	if a.ThumbnailURL != first.ThumbnailURL || a.Description != first.Description || a.SiteName != first.SiteName {
+		t.Errorf("OGP = {%q %q %q}, want the first values", a.ThumbnailURL, a.Description, a.SiteName)
+	}
+	if !a.OGPCheckedAt.Equal(first.OGPCheckedAt) {
+		t.Errorf("OGPCheckedAt = %v, want %v", a.OGPCheckedAt, first.OGPCheckedAt)
+	}
+	if !a.PublishedAt.Equal(first.PublishedAt) {
+		t.Errorf("PublishedAt = %v, want %v", a.PublishedAt, first.PublishedAt)
+	}
+}
+
+func testSaveArticlesReplacesTags(t *testing.T, repo ArticleRepository) {
+	ctx := context.Background()
+	a := model.Article{ID: "zenn-1", Title: "Docker入門", Tags: []string{"Docker", "Go"}}
+	if _, err := repo.SaveArticles(ctx, []model.Article{a}); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+	a.Tags = []string{"Docker"}
+	if _, err := repo.SaveArticles(ctx, []model.Article{a}); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+
+	// 保存のたびにタグを置き換えるため、外したタグでは一致しない
+	page, err := repo.GetArticles(ctx, model.ArticleQuery{Tags: []string{"Go"}})
+	if err != nil {
+		t.Fatalf("GetArticles() error = %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Errorf("GetArticles(Go) = %v, want none", articleIDs(page.Items))
+	}
+	page, err = repo.GetArticles(ctx, model.ArticleQuery{Tags: []string{"Docker"}})
+	if err != nil {
+		t.Fatalf("GetArticles() error = %v", err)
+	}
+	if len(page.Items) != 1 || !slices.Equal(page.Items[0].Tags, []string{"Docker"}) {
+		t.Errorf("GetArticles(Docker) = %+v, want zenn-1 with tags [Docker]", page.Items)
+	}
+}
+
+func testGetArticlesByIDs(t *testing.T, repo ArticleRepository) {
+	ctx := context.Background()
+	if _, err := repo.SaveArticles(ctx, []model.Article{
+		{ID: "qiita-1", Title: "Go入門", URL: "https://qiita.com/a", Tags: []string{"Go", "Docker"}, SiteName: "Qiita"},
+		{ID: "zenn-1", Title: "Rust入門", URL: "https://zenn.dev/b", Tags: []string{"Rust"}},
+	}); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+
+	got, err := repo.GetArticlesByIDs(ctx, []string{"qiita-1", "missing"})
+	if err != nil {
+		t.Fatalf("GetArticlesByIDs() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("GetArticlesByIDs() returned %d articles, want 1", len(got))
+	}
+	a := got["qiita-1"]
+	if a.Title != "Go入門" || a.SiteName != "Qiita" || !slices.Equal(a.Tags, []string{"Go", "Docker"}) {
+		t.Errorf("article = {%q %q %v}, want {Go入門 Qiita [Go Docker]}", a.Title, a.SiteName, a.Tags)
+	}
+}
+
+func testUserRepository(t *testing.T, repo UserRepository) {
+	ctx := context.Background()
+
+	// 存在しないユーザーはエラーではなくnilを返す
+	user, err := repo.GetUser(ctx, "missing")
+	if err != nil || user != nil {
+		t.Fatalf("GetUser(missing) = %+v, %v, want nil, nil", user, err)
+	}
+
+	if err := repo.UpdateUserTags(ctx, "user-1", []string{"Go", "Rust"}); err != nil {
+		t.Fatalf("UpdateUserTags() error = %v", err)
+	}
+	if err := repo.UpdateUserLang(ctx, "user-1", "en"); err != nil {
+		t.Fatalf("UpdateUserLang() error = %v", err)
+	}
+	// 言語の更新でタグは消えない
+	user, err = repo.GetUser(ctx, "user-1")
+	if err != nil || user == nil {
+		t.Fatalf("GetUser() = %+v, %v", user, err)
+	}
+	if !slices.Equal(user.Tags, []string{"Go", "Rust"}) || user.Lang != "en" {
+		t.Errorf("GetUser() = {%v %q}, want {[Go Rust] en}", user.Tags, user.Lang)
+	}
+
+	if err := repo.UpdateUserTags(ctx, "user-1", []string{"Python"}); err != nil {
+		t.Fatalf("UpdateUserTags() error = %v", err)
+	}
+	user, err = repo.GetUser(ctx, "user-1")
+	if err != nil || user == nil {
+		t.Fatalf("GetUser() = %+v, %v", user, err)
+	}
+	if !slices.Equal(user.Tags, []string{"Python"}) || user.Lang != "en" {
+		t.Errorf("GetUser() = {%v %q}, want {[Python] en}", user.Tags, user.Lang)
+	}
+
+	// 言語のみを設定したユーザーも作成される
+	if err := repo.UpdateUserLang(ctx, "user-2", "ja"); err != nil {
+		t.Fatalf("UpdateUserLang() error = %v", err)
+	}
+	user, err = repo.GetUser(ctx, "user-2")
+	if err != nil || user == nil {
+		t.Fatalf("GetUser() = %+v, %v", user, err)
+	}
+	if user.ID != "user-2" || len(user.Tags) != 0 || user.Lang != "ja" {
+		t.Errorf("GetUser() = %+v, want user-2 with lang ja", user)
+	}
+}
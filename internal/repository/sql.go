@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// database/sqlのドライバー
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+)
+
+// SQLDialect はSQLデータベースの種類です。
+type SQLDialect string
+
+const (
+	// SQLite はSQLiteを使用します。
+	SQLite SQLDialect = "sqlite"
+	// Postgres はPostgreSQLを使用します。
+	Postgres SQLDialect = "postgres"
+)
+
+// database/sqlのドライバー名
+func (d SQLDialect) driverName() string {
+	if d == Postgres {
+		return "pgx"
+	}
+	return "sqlite"
+}
+
+// rebind はクエリ中のプレースホルダー "?" をデータベースの形式に変換します（PostgreSQLは $1, $2, ...）。
+func (d SQLDialect) rebind(query string) string {
+	if d != Postgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// 自動採番の主キーの列定義
+func (d SQLDialect) autoIncrementPK() string {
+	if d == Postgres {
+		return "BIGSERIAL PRIMARY KEY"
+	}
+	return "INTEGER PRIMARY KEY AUTOINCREMENT"
+}
+
+// OpenSQL はSQLデータベースに接続し、スキーマを最新のバージョンに移行します。
+func OpenSQL(ctx context.Context, dialect SQLDialect, dsn string) (*sql.DB, error) {
+	switch dialect {
+	case SQLite:
+		// 外部キー制約を有効にし、書き込みが競合した場合は待つ
+		if !strings.Contains(dsn, "_pragma=") {
+			sep := "?"
+			if strings.Contains(dsn, "?") {
+				sep = "&"
+			}
+			dsn += sep + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+		}
+	case Postgres:
+	default:
+		return nil, fmt.Errorf("unsupported sql dialect: %q", dialect)
+	}
+
+	db, err := sql.Open(dialect.driverName(), dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", dialect, err)
+	}
+	if dialect == SQLite {
+		// SQLiteは同時に1つの接続からしか書き込めないため、接続を1つに限定する
+		db.SetMaxOpenConns(1)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to %s database: %w", dialect, err)
+	}
+	if err := migrateSQL(ctx, db, dialect); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// タグ名からタグIDを取得する。存在しない場合は作成する
+func ensureTagID(ctx context.Context, tx *sql.Tx, dialect SQLDialect, name string) (int64, error) {
+	if _, err := tx.ExecContext(ctx, dialect.rebind(`INSERT INTO tags (name) VALUES (?) ON CONFLICT (name) DO NOTHING`), name); err != nil {
+		return 0, fmt.Errorf("failed to insert tag %q: %w", name, err)
+	}
+	var id int64
+	if err := tx.QueryRowContext(ctx, dialect.rebind(`SELECT id FROM tags WHERE name = ?`), name).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get tag %q: %w", name, err)
+	}
+	return id, nil
+}
+
+// 日時はUTCのRFC3339形式の文字列で保存する（文字列の順序が日時の順序と一致する）。不明な日時（ゼロ値）は空文字列にする
+func formatSQLTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatSQLTimeで保存した日時を読み込む。空文字列や不正な値はゼロ値にする
+func parseSQLTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// n個のプレースホルダー "?, ?, ..." を作る
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
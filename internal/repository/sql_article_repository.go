@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// sqlArticleRepository はSQLデータベースをデータストアとして使用するArticleRepositoryの実装です。
+// タグはtagsテーブルとarticle_tagsテーブル（多対多）で管理します。
+type sqlArticleRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+// NewSQLArticleRepository はsqlArticleRepositoryの新しいインスタンスを作成します。
+// dbはOpenSQLでスキーマを移行済みである必要があります。
+func NewSQLArticleRepository(db *sql.DB, dialect SQLDialect) ArticleRepository {
+	return &sqlArticleRepository{db: db, dialect: dialect}
+}
+
+// 著者やOGP情報がない記事で以前の値を消さないよう、Firestoreの実装と同様に値があるものだけ更新する
+const upsertArticleQuery = `
+INSERT INTO articles (
+	id, title, url, likes, published_at, source, fetched_at, lang, excerpt, word_count,
+	author_id, author_name, author_avatar_url, author_profile_url,
+	thumbnail_url, description, site_name, ogp_checked_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+	title = excluded.title,
+	url = excluded.url,
+	likes = excluded.likes,
+	published_at = excluded.published_at,
+	source = excluded.source,
+	fetched_at = excluded.fetched_at,
+	lang = excluded.lang,
+	excerpt = excluded.excerpt,
+	word_count = excluded.word_count,
+	author_id = COALESCE(excluded.author_id, articles.author_id),
+	author_name = COALESCE(excluded.author_name, articles.author_name),
+	author_avatar_url = COALESCE(excluded.author_avatar_url, articles.author_avatar_url),
+	author_profile_url = COALESCE(excluded.author_profile_url, articles.author_profile_url),
+	thumbnail_url = COALESCE(NULLIF(excluded.thumbnail_url, ''), articles.thumbnail_url),
+	description = COALESCE(NULLIF(excluded.description, ''), articles.description),
+	site_name = COALESCE(NULLIF(excluded.site_name, ''), articles.site_name),
+	ogp_checked_at = COALESCE(NULLIF(excluded.ogp_checked_at, ''), articles.ogp_checked_at)`
+
+// SQLデータベースに記事を保存
+// Firestoreの実装と同様に500件ごとのトランザクションに分け、失敗したトランザクションの記事は失敗として数える
+func (r *sqlArticleRepository) SaveArticles(ctx context.Context, articles []model.Article) (model.SaveResult, error) {
+	var result model.SaveResult
+	var errs []error
+	for start := 0; start < len(articles); start += maxBatchWrites {
+		chunk := articles[start:min(start+maxBatchWrites, len(articles))]
+		if err := r.saveChunk(ctx, chunk); err != nil {
+			result.Failed += len(chunk)
+			for _, a := range chunk {
+				result.FailedIDs = append(result.FailedIDs, a.DocumentID())
+			}
+			errs = append(errs, fmt.Errorf("articles %d-%d: %w", start, start+len(chunk)-1, err))
+			continue
+		}
+		result.Written += len(chunk)
+	}
+	if len(errs) > 0 {
+		return result, fmt.Errorf("failed to save %d of %d articles: %w", result.Failed, len(articles), errors.Join(errs...))
+	}
+	return result, nil
+}
+
+func (r *sqlArticleRepository) saveChunk(ctx context.Context, articles []model.Article) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, a := range articles {
+		id := a.DocumentID()
+		var authorID, authorName, authorAvatar, authorProfile sql.NullString
+		if a.Author != nil {
+			authorID = sql.NullString{String: a.Author.ID, Valid: true}
+			authorName = sql.NullString{String: a.Author.Name, Valid: true}
+			authorAvatar = sql.NullString{String: a.Author.AvatarURL, Valid: true}
+			authorProfile = sql.NullString{String: a.Author.ProfileURL, Valid: true}
+		}
+		if _, err := tx.ExecContext(ctx, r.dialect.rebind(upsertArticleQuery),
+			id, a.Title, a.URL, a.Likes, formatSQLTime(a.PublishedAt), a.Source, formatSQLTime(a.FetchedAt), a.Lang, a.Excerpt, a.WordCount,
+			authorID, authorName, authorAvatar, authorProfile,
+			a.ThumbnailURL, a.Description, a.SiteName, formatSQLTime(a.OGPCheckedAt),
+		); err != nil {
+			return fmt.Errorf("failed to upsert article %s: %w", id, err)
+		}
+
+		// タグは保存のたびに置き換える
+		if _, err := tx.ExecContext(ctx, r.dialect.rebind(`DELETE FROM article_tags WHERE article_id = ?`), id); err != nil {
+			return fmt.Errorf("failed to delete tags of article %s: %w", id, err)
+		}
+		for i, tag := range a.Tags {
+			tagID, err := ensureTagID(ctx, tx, r.dialect, tag)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, r.dialect.rebind(`INSERT INTO article_tags (article_id, tag_id, position) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`),
+				id, tagID, i); err != nil {
+				return fmt.Errorf("failed to insert tag of article %s: %w", id, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SQLデータベースから記事を取得（タグ・著者・言語でフィルタ。複数のタグはいずれかを持つ記事）
+// Firestoreの実装と同様にいいね数の降順（同数の場合はIDの降順）に並べ、カーソルの次の記事から1ページ分を返す
+func (r *sqlArticleRepository) GetArticles(ctx context.Context, query model.ArticleQuery) (model.ArticlePage, error) {
+	var conds []string
+	var args []interface{}
+	if len(query.Tags) > 0 {
+		conds = append(conds, `EXISTS (SELECT 1 FROM article_tags art JOIN tags t ON t.id = art.tag_id WHERE art.article_id = a.id AND t.name IN (`+placeholders(len(query.Tags))+`))`)
+		for _, tag := range query.Tags {
+			args = append(args, tag)
+		}
+	}
+	if query.Author != "" {
+		conds = append(conds, `a.author_id = ?`)
+		args = append(args, query.Author)
+	}
+	if query.Lang != "" {
+		conds = append(conds, `a.lang = ?`)
+		args = append(args, query.Lang)
+	}
+	if query.Cursor != nil {
+		conds = append(conds, `(a.likes < ? OR (a.likes = ? AND a.id < ?))`)
+		args = append(args, query.Cursor.Likes, query.Cursor.Likes, query.Cursor.ID)
+	}
+
+	q := `SELECT ` + articleColumns + ` FROM articles a`
+	if len(conds) > 0 {
+		q += ` WHERE ` + strings.Join(conds, ` AND `)
+	}
+	// 次のページがあるかを判定するため1件多く取得する
+	limit := query.PageSize()
+	q += ` ORDER BY a.likes DESC, a.id DESC LIMIT ?`
+	args = append(args, limit+1)
+
+	articles, err := r.queryArticles(ctx, q, args...)
+	if err != nil {
+		return model.ArticlePage{}, err
+	}
+
+	page := model.ArticlePage{Items: []model.Article{}}
+	if len(articles) > limit {
+		articles = articles[:limit]
+		last := articles[len(articles)-1]
+		page.NextCursor = (&model.ArticleCursor{Likes: last.Likes, ID: last.ID}).Encode()
+	}
+	if err := r.loadTags(ctx, articles); err != nil {
+		return model.ArticlePage{}, err
+	}
+	page.Items = append(page.Items, articles...)
+	return page, nil
+}
+
+// articleColumns はqueryArticlesで読み込む記事の列です。
+const articleColumns = `a.id, a.title, a.url, a.likes, a.published_at, a.source, a.fetched_at, a.lang, a.excerpt, a.word_count,
+	a.author_id, a.author_name, a.author_avatar_url, a.author_profile_url,
+	a.thumbnail_url, a.description, a.site_name, a.ogp_checked_at`
+
+// articleColumnsを選択するクエリを実行し、記事に変換する（タグは含まない）
+func (r *sqlArticleRepository) queryArticles(ctx context.Context, q string, args ...interface{}) ([]model.Article, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.rebind(q), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query articles: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []model.Article
+	for rows.Next() {
+		var a model.Article
+		var publishedAt, fetchedAt, ogpCheckedAt string
+		var authorID, authorName, authorAvatar, authorProfile sql.NullString
+		if err := rows.Scan(&a.ID, &a.Title, &a.URL, &a.Likes, &publishedAt, &a.Source, &fetchedAt, &a.Lang, &a.Excerpt, &a.WordCount,
+			&authorID, &authorName, &authorAvatar, &authorProfile,
+			&a.ThumbnailURL, &a.Description, &a.SiteName, &ogpCheckedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan article: %w", err)
+		}
+		a.PublishedAt = parseSQLTime(publishedAt)
+		a.FetchedAt = parseSQLTime(fetchedAt)
+		a.OGPCheckedAt = parseSQLTime(ogpCheckedAt)
+		if authorID.Valid {
+			a.Author = &model.Author{
+				ID:         authorID.String,
+				Name:       authorName.String,
+				AvatarURL:  authorAvatar.String,
+				ProfileURL: authorProfile.String,
+			}
+		}
+		articles = append(articles, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read articles: %w", err)
+	}
+	return articles, nil
+}
+
+// SQLデータベースからIDを指定して記事を取得（見つからないIDは結果に含めない）
+func (r *sqlArticleRepository) GetArticlesByIDs(ctx context.Context, ids []string) (map[string]model.Article, error) {
+	found := make(map[string]model.Article, len(ids))
+	for start := 0; start < len(ids); start += maxBatchWrites {
+		chunk := ids[start:min(start+maxBatchWrites, len(ids))]
+		args := make([]interface{}, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+		articles, err := r.queryArticles(ctx, `SELECT `+articleColumns+` FROM articles a WHERE a.id IN (`+placeholders(len(chunk))+`)`, args...)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.loadTags(ctx, articles); err != nil {
+			return nil, err
+		}
+		for _, a := range articles {
+			found[a.ID] = a
+		}
+	}
+	return found, nil
+}
+
+// 記事のタグを保存した順序で読み込む
+func (r *sqlArticleRepository) loadTags(ctx context.Context, articles []model.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+	index := make(map[string]int, len(articles))
+	args := make([]interface{}, 0, len(articles))
+	for i, a := range articles {
+		index[a.ID] = i
+		args = append(args, a.ID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.rebind(`SELECT art.article_id, t.name FROM article_tags art JOIN tags t ON t.id = art.tag_id
+		WHERE art.article_id IN (`+placeholders(len(args))+`) ORDER BY art.article_id, art.position`), args...)
+	if err != nil {
+		return fmt.Errorf("failed to query article tags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var articleID, name string
+		if err := rows.Scan(&articleID, &name); err != nil {
+			return fmt.Errorf("failed to scan article tag: %w", err)
+		}
+		if i, ok := index[articleID]; ok {
+			articles[i].Tags = append(articles[i].Tags, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read article tags: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// テスト用の一時ファイルにSQLiteのデータベースを作成し、スキーマを移行する
+func newSQLiteDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := OpenSQL(context.Background(), SQLite, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenSQL() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSQLArticleRepository(t *testing.T) {
+	testArticleRepository(t, func(t *testing.T) ArticleRepository {
+		return NewSQLArticleRepository(newSQLiteDB(t), SQLite)
+	})
+}
+
+func TestSQLArticleRepositoryPaginationWithTies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSQLArticleRepository(newSQLiteDB(t), SQLite)
+
+	// 同じいいね数の記事はIDの降順に並び、ページの境界をまたいでも重複・欠落しない
+	articles := []model.Article{{ID: "top", Likes: 100}}
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		articles = append(articles, model.Article{ID: id, Likes: 10})
+	}
+	articles = append(articles, model.Article{ID: "z", Likes: 1})
+	if _, err := repo.SaveArticles(ctx, articles); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+
+	var got []string
+	var cursors []string
+	query := model.ArticleQuery{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("too many pages")
+		}
+		page, err := repo.GetArticles(ctx, query)
+		if err != nil {
+			t.Fatalf("GetArticles() error = %v", err)
+		}
+		got = append(got, articleIDs(page.Items)...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursors = append(cursors, page.NextCursor)
+		cursor, err := model.DecodeArticleCursor(page.NextCursor)
+		if err != nil {
+			t.Fatalf("DecodeArticleCursor() error = %v", err)
+		}
+		query.Cursor = cursor
+	}
+	if want := []string{"top", "e", "d", "c", "b", "a", "z"}; !slices.Equal(got, want) {
+		t.Errorf("paged articles = %v, want %v", got, want)
+	}
+	// 最後のページがちょうど埋まらない場合、最後のカーソルの次のページで終わる
+	if len(cursors) != 3 {
+		t.Errorf("got %d cursors, want 3", len(cursors))
+	}
+}
+
+func TestSQLArticleRepositoryTags(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDB(t)
+	repo := NewSQLArticleRepository(db, SQLite)
+
+	if _, err := repo.SaveArticles(ctx, []model.Article{
+		{ID: "a", Likes: 3, Tags: []string{"Go", "Docker", "AWS"}},
+		{ID: "b", Likes: 2, Tags: []string{"Docker"}},
+		{ID: "c", Likes: 1, Tags: []string{"Rust"}},
+	}); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+
+	// 複数のタグに一致する記事も1件として返し、タグは保存した順に読み込む
+	page, err := repo.GetArticles(ctx, model.ArticleQuery{Tags: []string{"Go", "Docker"}})
+	if err != nil {
+		t.Fatalf("GetArticles() error = %v", err)
+	}
+	if got := articleIDs(page.Items); !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("GetArticles() = %v, want [a b]", got)
+	}
+	if got := page.Items[0].Tags; !slices.Equal(got, []string{"Go", "Docker", "AWS"}) {
+		t.Errorf("tags = %v, want [Go Docker AWS]", got)
+	}
+
+	// 同じ名前のタグは1行にまとめる
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM tags WHERE name = 'Docker'`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("tags named Docker = %d, want 1", n)
+	}
+}
+
+func TestSQLArticleRepositoryUpsertReplacesAuthorAndOGP(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSQLArticleRepository(newSQLiteDB(t), SQLite)
+
+	first := model.Article{
+		ID:           "a",
+		Author:       &model.Author{ID: "gopher", Name: "Gopher", AvatarURL: "https://example.com/gopher.png"},
+		ThumbnailURL: "https://example.com/old.png",
+		SiteName:     "Old",
+	}
+	second := model.Article{
+		ID:           "a",
+		Author:       &model.Author{ID: "ferris", Name: "Ferris"},
+		ThumbnailURL: "https://example.com/new.png",
+	}
+	if _, err := repo.SaveArticles(ctx, []model.Article{first}); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+	if _, err := repo.SaveArticles(ctx, []model.Article{second}); err != nil {
+		t.Fatalf("SaveArticles() error = %v", err)
+	}
+
+	got, err := repo.GetArticlesByIDs(ctx, []string{"a"})
+	if err != nil {
+		t.Fatalf("GetArticlesByIDs() error = %v", err)
+	}
+	a := got["a"]
+	// 著者は記事単位で置き換え、以前の著者の項目を混ぜない
+	if a.Author == nil || *a.Author != *second.Author {
+		t.Errorf("Author = %+v, want %+v", a.Author, second.Author)
+	}
+	// OGPは値のある項目のみ置き換える
+	if a.ThumbnailURL != second.ThumbnailURL || a.SiteName != first.SiteName {
+		t.Errorf("OGP = {%q %q}, want {%q %q}", a.ThumbnailURL, a.SiteName, second.ThumbnailURL, first.SiteName)
+	}
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// sqlCheckpointRepository はSQLデータベースをデータストアとして使用するCheckpointRepositoryの実装です。
+type sqlCheckpointRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+// NewSQLCheckpointRepository はsqlCheckpointRepositoryの新しいインスタンスを作成します。
+// dbはOpenSQLでスキーマを移行済みである必要があります。
+func NewSQLCheckpointRepository(db *sql.DB, dialect SQLDialect) CheckpointRepository {
+	return &sqlCheckpointRepository{db: db, dialect: dialect}
+}
+
+// SQLデータベースから前回の取得日時を取得
+func (r *sqlCheckpointRepository) LastFetched(ctx context.Context, source, tag string) (time.Time, bool, error) {
+	var v string
+	err := r.db.QueryRowContext(ctx, r.dialect.rebind(`SELECT last_fetched_at FROM fetch_checkpoints WHERE source = ? AND tag = ?`), source, tag).Scan(&v)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to get checkpoint from database: %w", err)
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to parse checkpoint %q: %w", v, err)
+	}
+	return t, true, nil
+}
+
+// SQLデータベースに取得日時を記録
+func (r *sqlCheckpointRepository) SetLastFetched(ctx context.Context, source, tag string, t time.Time) error {
+	_, err := r.db.ExecContext(ctx, r.dialect.rebind(`INSERT INTO fetch_checkpoints (source, tag, last_fetched_at) VALUES (?, ?, ?)
+		ON CONFLICT (source, tag) DO UPDATE SET last_fetched_at = excluded.last_fetched_at`),
+		source, tag, t.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return fmt.Errorf("failed to set checkpoint in database: %w", err)
+	}
+	return nil
+}
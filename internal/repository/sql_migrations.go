@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// sqlMigration はスキーマの1つのバージョンへの移行です。
+type sqlMigration struct {
+	version     int
+	description string
+	statements  func(d SQLDialect) []string
+	// run はSQL文で表せないデータの変換です。statementsの後に同じトランザクションで実行します。
+	run func(ctx context.Context, tx *sql.Tx, d SQLDialect) error
+}
+
+// sqlMigrations はスキーマの移行の一覧です。適用済みのものは変更せず、変更は新しいバージョンとして末尾に追加します。
+var sqlMigrations = []sqlMigration{
+	{
+		version:     1,
+		description: "create articles, users, tags and checkpoints",
+		statements: func(d SQLDialect) []string {
+			return []string{
+				`CREATE TABLE articles (
+					id                 TEXT PRIMARY KEY,
+					title              TEXT NOT NULL DEFAULT '',
+					url                TEXT NOT NULL DEFAULT '',
+					likes              INTEGER NOT NULL DEFAULT 0,
+					published_at       TEXT NOT NULL DEFAULT '',
+					source             TEXT NOT NULL DEFAULT '',
+					fetched_at         TEXT NOT NULL DEFAULT '',
+					lang               TEXT NOT NULL DEFAULT '',
+					excerpt            TEXT NOT NULL DEFAULT '',
+					word_count         INTEGER NOT NULL DEFAULT 0,
+					author_id          TEXT,
+					author_name        TEXT,
+					author_avatar_url  TEXT,
+					author_profile_url TEXT,
+					thumbnail_url      TEXT NOT NULL DEFAULT '',
+					description        TEXT NOT NULL DEFAULT '',
+					site_name          TEXT NOT NULL DEFAULT ''
+				)`,
+				`CREATE INDEX articles_likes_idx ON articles (likes DESC, id)`,
+				`CREATE INDEX articles_author_idx ON articles (author_id)`,
+				`CREATE INDEX articles_lang_idx ON articles (lang)`,
+				fmt.Sprintf(`CREATE TABLE tags (
+					id   %s,
+					name TEXT NOT NULL UNIQUE
+				)`, d.autoIncrementPK()),
+				`CREATE TABLE article_tags (
+					article_id TEXT NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+					tag_id     BIGINT NOT NULL REFERENCES tags (id),
+					position   INTEGER NOT NULL,
+					PRIMARY KEY (article_id, tag_id)
+				)`,
+				`CREATE INDEX article_tags_tag_idx ON article_tags (tag_id)`,
+				`CREATE TABLE users (
+					id         TEXT PRIMARY KEY,
+					name       TEXT NOT NULL DEFAULT '',
+					email      TEXT NOT NULL DEFAULT '',
+					lang       TEXT NOT NULL DEFAULT '',
+					updated_at TEXT NOT NULL DEFAULT ''
+				)`,
+				`CREATE TABLE user_tags (
+					user_id  TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+					tag_id   BIGINT NOT NULL REFERENCES tags (id),
+					position INTEGER NOT NULL,
+					PRIMARY KEY (user_id, tag_id)
+				)`,
+				`CREATE TABLE fetch_checkpoints (
+					source          TEXT NOT NULL,
+					tag             TEXT NOT NULL,
+					last_fetched_at TEXT NOT NULL,
+					PRIMARY KEY (source, tag)
+				)`,
+			}
+		},
+	},
+	{
+		version:     2,
+		description: "normalize article timestamps to UTC and index published_at",
+		statements: func(d SQLDialect) []string {
+			return []string{
+				`CREATE INDEX articles_published_at_idx ON articles (published_at)`,
+			}
+		},
+		run: normalizeArticleTimes,
+	},
+	{
+		version:     3,
+		description: "create http_validators",
+		statements: func(d SQLDialect) []string {
+			return []string{
+				`CREATE TABLE http_validators (
+					url           TEXT PRIMARY KEY,
+					etag          TEXT NOT NULL DEFAULT '',
+					last_modified TEXT NOT NULL DEFAULT ''
+				)`,
+			}
+		},
+	},
+	{
+		version:     4,
+		description: "add ogp_checked_at to articles",
+		statements: func(d SQLDialect) []string {
+			return []string{
+				`ALTER TABLE articles ADD COLUMN ogp_checked_at TEXT NOT NULL DEFAULT ''`,
+			}
+		},
+	},
+}
+
+// 記事の日時をUTCのRFC3339形式に揃える。以前はソースのタイムゾーンのまま保存しており、
+// 公開日時をパースできなかった記事には "0001-01-01T00:00:00Z" が保存されていたため空文字列にする
+func normalizeArticleTimes(ctx context.Context, tx *sql.Tx, d SQLDialect) error {
+	type row struct{ id, publishedAt, fetchedAt string }
+	rows, err := tx.QueryContext(ctx, `SELECT id, published_at, fetched_at FROM articles`)
+	if err != nil {
+		return err
+	}
+	var updates []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.id, &r.publishedAt, &r.fetchedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		normalized := row{r.id, formatSQLTime(parseSQLTime(r.publishedAt)), formatSQLTime(parseSQLTime(r.fetchedAt))}
+		if normalized != r {
+			updates = append(updates, normalized)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, r := range updates {
+		if _, err := tx.ExecContext(ctx, d.rebind(`UPDATE articles SET published_at = ?, fetched_at = ? WHERE id = ?`),
+			r.publishedAt, r.fetchedAt, r.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 未適用のスキーマの移行をバージョン順に適用する。各バージョンは1つのトランザクションで適用する
+func migrateSQL(ctx context.Context, db *sql.DB, dialect SQLDialect) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var current int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	for _, m := range sqlMigrations {
+		if m.version <= current {
+			continue
+		}
+		if err := applySQLMigration(ctx, db, dialect, m); err != nil {
+			return fmt.Errorf("failed to apply migration %d (%s): %w", m.version, m.description, err)
+		}
+	}
+	return nil
+}
+
+func applySQLMigration(ctx context.Context, db *sql.DB, dialect SQLDialect, m sqlMigration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range m.statements(dialect) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	if m.run != nil {
+		if err := m.run(ctx, tx, dialect); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, dialect.rebind(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`),
+		m.version, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSQLMigrationsVersionOrder(t *testing.T) {
+	for i, m := range sqlMigrations {
+		if m.version != i+1 {
+			t.Errorf("sqlMigrations[%d].version = %d, want %d", i, m.version, i+1)
+		}
+	}
+}
+
+func TestMigrateSQLAppliesPendingVersionsInOrder(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var applied []int
+	record := func(version int) sqlMigration {
+		return sqlMigration{
+			version:     version,
+			description: "test",
+			statements:  func(d SQLDialect) []string { return nil },
+			run: func(ctx context.Context, tx *sql.Tx, d SQLDialect) error {
+				applied = append(applied, version)
+				return nil
+			},
+		}
+	}
+	orig := sqlMigrations
+	t.Cleanup(func() { sqlMigrations = orig })
+
+	sqlMigrations = []sqlMigration{record(1), record(2)}
+	if err := migrateSQL(ctx, db, SQLite); err != nil {
+		t.Fatalf("migrateSQL() error = %v", err)
+	}
+	// 追加したバージョンのみを順に適用する
+	sqlMigrations = []sqlMigration{record(1), record(2), record(3), record(4)}
+	if err := migrateSQL(ctx, db, SQLite); err != nil {
+		t.Fatalf("migrateSQL() error = %v", err)
+	}
+	if want := []int{1, 2, 3, 4}; !slices.Equal(applied, want) {
+		t.Errorf("applied migrations = %v, want %v", applied, want)
+	}
+
+	var versions []int
+	rows, err := db.QueryContext(ctx, `SELECT version FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, v)
+	}
+	if want := []int{1, 2, 3, 4}; !slices.Equal(versions, want) {
+		t.Errorf("schema_migrations = %v, want %v", versions, want)
+	}
+}
+
+func TestOpenSQLUpgradesExistingDatabase(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	// バージョン1のスキーマで、タイムゾーン付きの日時を保存した古いデータベース
+	orig := sqlMigrations
+	sqlMigrations = orig[:1]
+	db, err := OpenSQL(ctx, SQLite, path)
+	sqlMigrations = orig
+	if err != nil {
+		t.Fatalf("OpenSQL() error = %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO articles (id, published_at, fetched_at) VALUES ('a', '2024-07-01T10:00:00+09:00', '0001-01-01T00:00:00Z')`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	db, err = OpenSQL(ctx, SQLite, path)
+	if err != nil {
+		t.Fatalf("OpenSQL() error = %v", err)
+	}
+	defer db.Close()
+
+	var publishedAt, fetchedAt string
+	if err := db.QueryRowContext(ctx, `SELECT published_at, fetched_at FROM articles WHERE id = 'a'`).Scan(&publishedAt, &fetchedAt); err != nil {
+		t.Fatal(err)
+	}
+	if publishedAt != "2024-07-01T01:00:00Z" || fetchedAt != "" {
+		t.Errorf("times = {%q %q}, want {2024-07-01T01:00:00Z \"\"}", publishedAt, fetchedAt)
+	}
+	// 以降のバージョンで追加したテーブルも作成される
+	if _, err := db.ExecContext(ctx, `SELECT COUNT(*) FROM http_validators`); err != nil {
+		t.Errorf("http_validators table is missing: %v", err)
+	}
+}
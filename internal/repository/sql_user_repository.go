@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// sqlUserRepository はSQLデータベースをデータストアとして使用するUserRepositoryの実装です。
+type sqlUserRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+// NewSQLUserRepository はsqlUserRepositoryの新しいインスタンスを作成します。
+// dbはOpenSQLでスキーマを移行済みである必要があります。
+func NewSQLUserRepository(db *sql.DB, dialect SQLDialect) UserRepository {
+	return &sqlUserRepository{db: db, dialect: dialect}
+}
+
+// SQLデータベースからユーザー情報を取得
+func (r *sqlUserRepository) GetUser(ctx context.Context, userID string) (*model.User, error) {
+	var user model.User
+	err := r.db.QueryRowContext(ctx, r.dialect.rebind(`SELECT id, name, email, lang FROM users WHERE id = ?`), userID).
+		Scan(&user.ID, &user.Name, &user.Email, &user.Lang)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil // ユーザーが存在しない場合はエラーではなくnilユーザーを返す
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user from database: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.dialect.rebind(`SELECT t.name FROM user_tags ut JOIN tags t ON t.id = ut.tag_id
+		WHERE ut.user_id = ? ORDER BY ut.position`), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user tags: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan user tag: %w", err)
+		}
+		user.Tags = append(user.Tags, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read user tags: %w", err)
+	}
+	return &user, nil
+}
+
+// SQLデータベースでユーザーのタグを更新（ユーザーが存在しない場合は作成）
+func (r *sqlUserRepository) UpdateUserTags(ctx context.Context, userID string, tags []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.touchUser(ctx, tx, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, r.dialect.rebind(`DELETE FROM user_tags WHERE user_id = ?`), userID); err != nil {
+		return fmt.Errorf("failed to delete user tags: %w", err)
+	}
+	for i, tag := range tags {
+		tagID, err := ensureTagID(ctx, tx, r.dialect, tag)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, r.dialect.rebind(`INSERT INTO user_tags (user_id, tag_id, position) VALUES (?, ?, ?) ON CONFLICT DO NOTHING`),
+			userID, tagID, i); err != nil {
+			return fmt.Errorf("failed to insert user tag: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to update user tags in database: %w", err)
+	}
+	return nil
+}
+
+// SQLデータベースでユーザーの優先言語を更新（ユーザーが存在しない場合は作成）
+func (r *sqlUserRepository) UpdateUserLang(ctx context.Context, userID string, lang string) error {
+	_, err := r.db.ExecContext(ctx, r.dialect.rebind(`INSERT INTO users (id, lang, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET lang = excluded.lang, updated_at = excluded.updated_at`),
+		userID, lang, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to update user lang in database: %w", err)
+	}
+	return nil
+}
+
+// ユーザーを作成または更新日時を更新する
+func (r *sqlUserRepository) touchUser(ctx context.Context, tx *sql.Tx, userID string) error {
+	_, err := tx.ExecContext(ctx, r.dialect.rebind(`INSERT INTO users (id, updated_at) VALUES (?, ?)
+		ON CONFLICT (id) DO UPDATE SET updated_at = excluded.updated_at`),
+		userID, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to upsert user: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "testing"
+
+func TestSQLUserRepository(t *testing.T) {
+	testUserRepository(t, NewSQLUserRepository(newSQLiteDB(t), SQLite))
+}
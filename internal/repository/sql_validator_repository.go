@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/fetcher"
+)
+
+// sqlValidatorRepository はSQLデータベースをデータストアとして使用するValidatorRepositoryの実装です。
+type sqlValidatorRepository struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+// NewSQLValidatorRepository はsqlValidatorRepositoryの新しいインスタンスを作成します。
+// dbはOpenSQLでスキーマを移行済みである必要があります。
+func NewSQLValidatorRepository(db *sql.DB, dialect SQLDialect) ValidatorRepository {
+	return &sqlValidatorRepository{db: db, dialect: dialect}
+}
+
+// SQLデータベースから検証子を取得
+func (r *sqlValidatorRepository) Get(ctx context.Context, url string) (fetcher.Validators, bool, error) {
+	var v fetcher.Validators
+	err := r.db.QueryRowContext(ctx, r.dialect.rebind(`SELECT etag, last_modified FROM http_validators WHERE url = ?`), url).
+		Scan(&v.ETag, &v.LastModified)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fetcher.Validators{}, false, nil
+	}
+	if err != nil {
+		return fetcher.Validators{}, false, fmt.Errorf("failed to get validators from database: %w", err)
+	}
+	return v, true, nil
+}
+
+// SQLデータベースに検証子を保存
+func (r *sqlValidatorRepository) Set(ctx context.Context, url string, v fetcher.Validators) error {
+	_, err := r.db.ExecContext(ctx, r.dialect.rebind(`INSERT INTO http_validators (url, etag, last_modified) VALUES (?, ?, ?)
+		ON CONFLICT (url) DO UPDATE SET etag = excluded.etag, last_modified = excluded.last_modified`),
+		url, v.ETag, v.LastModified)
+	if err != nil {
+		return fmt.Errorf("failed to set validators in database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/fetcher"
+)
+
+func TestSQLValidatorRepository(t *testing.T) {
+	ctx := context.Background()
+	db, err := OpenSQL(ctx, SQLite, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenSQL() error = %v", err)
+	}
+	defer db.Close()
+	repo := NewSQLValidatorRepository(db, SQLite)
+
+	const url = "https://zenn.dev/topics/go/feed"
+	if _, ok, err := repo.Get(ctx, url); err != nil || ok {
+		t.Fatalf("Get() before Set = %v, %v, want not found", ok, err)
+	}
+
+	for _, want := range []fetcher.Validators{
+		{ETag: `"v1"`, LastModified: "Mon, 01 Jul 2024 10:00:00 GMT"},
+		{ETag: `"v2"`},
+	} {
+		if err := repo.Set(ctx, url, want); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		got, ok, err := repo.Get(ctx, url)
+		if err != nil || !ok || got != want {
+			t.Errorf("Get() = %+v, %v, %v, want %+v", got, ok, err, want)
+		}
+	}
+}
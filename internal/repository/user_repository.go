@@ -19,6 +19,7 @@ const userCollection = "users"
 type UserRepository interface {
 	GetUser(ctx context.Context, userID string) (*model.User, error)
 	UpdateUserTags(ctx context.Context, userID string, tags []string) error
+	UpdateUserLang(ctx context.Context, userID string, lang string) error
 	// 必要に応じて他のメソッドを追加
 }
 
@@ -60,3 +61,15 @@ func (r *firestoreUserRepository) UpdateUserTags(ctx context.Context, userID str
 	}
 	return nil
 }
+
+// Firestoreでユーザーの優先言語を更新
+func (r *firestoreUserRepository) UpdateUserLang(ctx context.Context, userID string, lang string) error {
+	_, err := r.client.Collection(userCollection).Doc(userID).Set(ctx, map[string]interface{}{
+		"lang":       lang,
+		"updated_at": time.Now().Format(time.RFC3339), // stringにフォーマット
+	}, firestore.MergeAll)
+	if err != nil {
+		return fmt.Errorf("failed to update user lang in firestore: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+
+	firestore "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/fetcher"
+)
+
+const validatorCollection = "http_validators"
+
+// ValidatorRepository はリクエストURLごとのHTTPの検証子（ETag/Last-Modified）へのアクセスを抽象化するインターフェースです。
+type ValidatorRepository interface {
+	Get(ctx context.Context, url string) (fetcher.Validators, bool, error)
+	Set(ctx context.Context, url string, v fetcher.Validators) error
+}
+
+// firestoreValidatorRepository はFirestoreをデータストアとして使用するValidatorRepositoryの実装です。
+type firestoreValidatorRepository struct {
+	client *firestore.Client
+}
+
+// NewValidatorRepository はfirestoreValidatorRepositoryの新しいインスタンスを作成します。
+func NewValidatorRepository(client *firestore.Client) ValidatorRepository {
+	return &firestoreValidatorRepository{client: client}
+}
+
+// ドキュメントIDはURLのハッシュ。URLは "/" を含み、長さの上限を超えることもあるため
+func validatorDocID(url string) string {
+	sum := sha1.Sum([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+// Firestoreから検証子を取得
+func (r *firestoreValidatorRepository) Get(ctx context.Context, url string) (fetcher.Validators, bool, error) {
+	dsnap, err := r.client.Collection(validatorCollection).Doc(validatorDocID(url)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return fetcher.Validators{}, false, nil
+		}
+		return fetcher.Validators{}, false, fmt.Errorf("failed to get validators from firestore: %w", err)
+	}
+	var data struct {
+		ETag         string `firestore:"etag"`
+		LastModified string `firestore:"lastModified"`
+	}
+	if err := dsnap.DataTo(&data); err != nil {
+		return fetcher.Validators{}, false, fmt.Errorf("failed to map firestore data to validators: %w", err)
+	}
+	return fetcher.Validators{ETag: data.ETag, LastModified: data.LastModified}, true, nil
+}
+
+// Firestoreに検証子を保存
+func (r *firestoreValidatorRepository) Set(ctx context.Context, url string, v fetcher.Validators) error {
+	_, err := r.client.Collection(validatorCollection).Doc(validatorDocID(url)).Set(ctx, map[string]interface{}{
+		"url":          url,
+		"etag":         v.ETag,
+		"lastModified": v.LastModified,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save validators to firestore: %w", err)
+	}
+	return nil
+}
@@ -2,78 +2,351 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"time"
 
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/fetcher"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/langdetect"
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/tagnorm"
 )
 
 // ArticleRepository は記事データへのアクセスインターフェースです。
 type ArticleRepository interface {
-	SaveArticles(ctx context.Context, articles []model.Article) error
-	GetArticlesByTag(ctx context.Context, tag string) ([]model.Article, error)
+	SaveArticles(ctx context.Context, articles []model.Article) (model.SaveResult, error)
+	GetArticles(ctx context.Context, query model.ArticleQuery) (model.ArticlePage, error)
+	GetArticlesByIDs(ctx context.Context, ids []string) (map[string]model.Article, error)
 	// 必要に応じて他のメソッドを追加
 }
 
+// Enricher は取得した記事に追加の情報を付与するインターフェースです。
+type Enricher interface {
+	Enrich(ctx context.Context, articles []model.Article)
+}
+
+// DefaultFetchWorkers は記事取得を並行して実行する数のデフォルト値です。
+const DefaultFetchWorkers = 4
+
+// ArticleServiceConfig はArticleServiceの設定です。
+type ArticleServiceConfig struct {
+	// Fetchers は記事の取得元です。結果はこの順に集計されます。
+	Fetchers []fetcher.Fetcher
+	// Workers はソース×タグの取得を並行して実行する数です。0以下の場合はデフォルト値を使用します。
+	Workers int
+	// Tags は記事のタグを正規化する辞書です。nilの場合は組み込みの辞書を使用します。
+	Tags *tagnorm.Dictionary
+	// Enricher は取得後、保存前に記事へ情報を付与します（OGP情報など）。nilの場合は何もしません。
+	Enricher Enricher
+	// Health はソースごとの稼働状況の記録先です。nilの場合はデフォルト設定のHealthTrackerを使用します。
+	Health *HealthTracker
+}
+
 // ArticleService は記事関連のビジネスロジックを扱います。
 type ArticleService struct {
-	repo ArticleRepository
+	repo     ArticleRepository
+	fetchers []fetcher.Fetcher
+	workers  int
+	tags     *tagnorm.Dictionary
+	enricher Enricher
+	health   *HealthTracker
 }
 
 // NewArticleService はArticleServiceの新しいインスタンスを作成します。
-func NewArticleService(repo ArticleRepository) *ArticleService {
-	return &ArticleService{repo: repo}
+func NewArticleService(repo ArticleRepository, cfg ArticleServiceConfig) *ArticleService {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = DefaultFetchWorkers
+	}
+	tags := cfg.Tags
+	if tags == nil {
+		tags = tagnorm.Default()
+	}
+	health := cfg.Health
+	if health == nil {
+		health = NewHealthTracker(0, 0)
+	}
+	return &ArticleService{
+		repo:     repo,
+		fetchers: cfg.Fetchers,
+		workers:  workers,
+		tags:     tags,
+		enricher: cfg.Enricher,
+		health:   health,
+	}
+}
+
+// SourceResult はソースごとの記事取得結果です。
+type SourceResult struct {
+	Source    string   `json:"source"`
+	Fetched   int      `json:"fetched"`   // 取得できた記事数
+	Unchanged int      `json:"unchanged"` // 前回の取得から変更がなかったタグ数
+	Failed    int      `json:"failed"`    // 取得に失敗したタグ数
+	Skipped   int      `json:"skipped"`   // 失敗が続いているため取得をスキップしたタグ数
+	Errors    []string `json:"errors,omitempty"`
+	// RateLimit はAPIの残りリクエスト数です。レート制限を報告できるソースのみ設定されます。
+	RateLimit *fetcher.RateLimit `json:"rateLimit,omitempty"`
+}
+
+// FetchRunResult は1回の記事取得・保存処理の結果です。
+type FetchRunResult struct {
+	Sources []SourceResult `json:"sources"`
+	Saved   int            `json:"saved"`            // 保存した記事数
+	Failed  int            `json:"failed,omitempty"` // 保存に失敗した記事数
+}
+
+// SourceHealth は登録されたソースごとの稼働状況を返します。
+func (s *ArticleService) SourceHealth() []model.SourceHealth {
+	statuses := make([]model.SourceHealth, 0, len(s.fetchers))
+	for _, f := range s.fetchers {
+		statuses = append(statuses, s.health.Status(f.Name()))
+	}
+	return statuses
 }
 
 // GetPopularArticles は人気記事を取得します。
 // 必要に応じて、定期実行処理からこの関数を呼び出し、
 // Fetcherで最新記事を取得してRepositoryで保存・更新する処理を実装します。
 // 現在はキャッシュからの取得のみを行います。
-func (s *ArticleService) GetPopularArticles(ctx context.Context, tag string) ([]model.Article, error) {
+// 結果はいいね数の降順でページに分けて返し、次のページはNextCursorをquery.Cursorに指定して取得します。
+func (s *ArticleService) GetPopularArticles(ctx context.Context, query model.ArticleQuery) (model.ArticlePage, error) {
 	// TODO: 定期実行処理でFetcherを呼び出し、Repository.SaveArticlesを呼び出す
 
+	// 保存済みの記事と同じ表記で検索する
+	query.Tags = s.tags.NormalizeAll(query.Tags)
+
+	// すべてのタグを持つ記事はデータストアで絞り込めないため、サービス層で絞り込む
+	if query.Match == model.TagMatchAll && len(query.Tags) > 1 {
+		return s.getArticlesWithAllTags(ctx, query)
+	}
+
 	// 現在はキャッシュから記事を取得して返すのみ
-	articles, err := s.repo.GetArticlesByTag(ctx, tag)
+	page, err := s.repo.GetArticles(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get articles from repository: %w", err)
+		return model.ArticlePage{}, fmt.Errorf("failed to get articles from repository: %w", err)
 	}
 
 	// TODO: 必要に応じて、キャッシュが古い場合はFetcherを呼び出して更新するロジックを追加
 
-	return articles, nil
+	return page, nil
+}
+
+// maxTagScanPages はすべてのタグを持つ記事を探すため、1回のリクエストで読むリポジトリのページ数の上限です。
+const maxTagScanPages = 5
+
+// すべてのタグを持つ記事を1ページ分返す
+// 1つ目のタグを持つ記事をリポジトリの並び順（いいね数の降順）で読み、残りのタグも持つ記事だけを集める。
+// カーソルは最後に読んだ記事の位置にするため、次のページは続きの記事から読み始める
+func (s *ArticleService) getArticlesWithAllTags(ctx context.Context, query model.ArticleQuery) (model.ArticlePage, error) {
+	limit := query.PageSize()
+	repoQuery := query
+	repoQuery.Tags = query.Tags[:1]
+	repoQuery.Match = model.TagMatchAny
+	repoQuery.Limit = model.MaxArticleLimit
+
+	page := model.ArticlePage{Items: []model.Article{}}
+	for i := 0; i < maxTagScanPages; i++ {
+		repoPage, err := s.repo.GetArticles(ctx, repoQuery)
+		if err != nil {
+			return model.ArticlePage{}, fmt.Errorf("failed to get articles from repository: %w", err)
+		}
+		for _, a := range repoPage.Items {
+			if !hasAllTags(a, query.Tags[1:]) {
+				continue
+			}
+			page.Items = append(page.Items, a)
+			if len(page.Items) == limit {
+				page.NextCursor = (&model.ArticleCursor{Likes: a.Likes, ID: a.DocumentID()}).Encode()
+				return page, nil
+			}
+		}
+		if repoPage.NextCursor == "" {
+			return page, nil
+		}
+		cursor, err := model.DecodeArticleCursor(repoPage.NextCursor)
+		if err != nil {
+			return model.ArticlePage{}, fmt.Errorf("failed to decode repository cursor: %w", err)
+		}
+		repoQuery.Cursor = cursor
+	}
+	// 読む上限に達した場合は、集めた記事が1ページに満たなくても続きをカーソルで返す
+	page.NextCursor = repoQuery.Cursor.Encode()
+	return page, nil
+}
+
+func hasAllTags(a model.Article, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(a.Tags, tag) {
+			return false
+		}
+	}
+	return true
 }
 
-// FetchAndSaveArticles はQiitaとZennから記事を取得し、リポジトリに保存します。
+// FetchAndSaveArticles は登録されたFetcherから記事を取得し、リポジトリに保存します。
 // この関数はバッチ処理や定期実行される関数から呼び出されることを想定しています。
-func (s *ArticleService) FetchAndSaveArticles(ctx context.Context, tags []string) error {
+// ソース×タグの取得は並行して実行され、一部が失敗しても他の取得は続行し、結果はソースごとに返します。
+func (s *ArticleService) FetchAndSaveArticles(ctx context.Context, tags []string) (*FetchRunResult, error) {
+	outcomes := fetchAll(ctx, s.fetchers, tags, s.workers, s.health)
+	// 取得日時は秒単位で揃える（JSONの形式を変えないため）
+	fetchedAt := time.Now().UTC().Truncate(time.Second)
+
+	// ソースごとに結果を集計（ソース・タグの順序は設定どおり）
 	var allArticles []model.Article
+	result := &FetchRunResult{}
+	for i, f := range s.fetchers {
+		sr := SourceResult{Source: f.Name()}
+		for j, tag := range tags {
+			o := outcomes[i*len(tags)+j]
+			if errors.Is(o.err, fetcher.ErrNotModified) {
+				// 変更がないため保存は不要
+				sr.Unchanged++
+				continue
+			}
+			if errors.Is(o.err, errCircuitOpen) {
+				sr.Skipped++
+				continue
+			}
+			if o.err != nil {
+				// エラーをログに出力して、処理を続行
+				log.Printf("Error fetching %s articles for tag %s: %v", f.Name(), tag, o.err)
+				sr.Failed++
+				sr.Errors = append(sr.Errors, fmt.Sprintf("%s: %v", tag, o.err))
+				continue
+			}
+			sr.Fetched += len(o.articles)
+			for _, a := range o.articles {
+				a.FetchedAt = fetchedAt
+				// ソースごとに異なるタグの表記を正規化
+				a.Tags = s.tags.NormalizeAll(a.Tags)
+				// ソースが言語を指定していない場合はタイトルと抜粋から推定
+				if a.Lang == "" {
+					a.Lang = langdetect.Detect(a.Title + "\n" + a.Excerpt)
+				}
+				allArticles = append(allArticles, a)
+			}
+		}
+		if reporter, ok := f.(fetcher.RateLimitReporter); ok {
+			if rl, ok := reporter.RateLimit(); ok {
+				sr.RateLimit = &rl
+			}
+		}
+		result.Sources = append(result.Sources, sr)
+	}
 
-	// 各タグごとに記事を取得
-	for _, tag := range tags {
-		qiitaArticles, err := fetcher.FetchQiitaArticles(tag)
-		if err != nil {
-			// エラーをログに出力するなどして、処理を続行
-			fmt.Printf("Error fetching Qiita articles for tag %s: %v\n", tag, err)
+	// 複数のタグで取得された同じ記事は、保存時にタグを上書きし合わないよう1件にまとめる
+	allArticles = mergeDuplicateArticles(allArticles)
+
+	if len(allArticles) == 0 {
+		log.Println("No new or updated articles to save.")
+		s.commit(ctx)
+		return result, nil
+	}
+
+	// 記事ページのOGP情報などを付与
+	if s.enricher != nil {
+		s.enrich(ctx, allArticles)
+	}
+
+	// リポジトリに保存（一部の記事の保存に失敗しても、保存できた件数は結果に含める）
+	saved, err := s.repo.SaveArticles(ctx, allArticles)
+	result.Saved = saved.Written
+	result.Failed = saved.Failed
+	if err != nil {
+		return result, fmt.Errorf("failed to save articles to repository: %w", err)
+	}
+
+	// 保存に失敗した記事がある場合は、次回も同じ記事を取得できるよう取得の進捗を確定しない
+	if saved.Failed == 0 {
+		s.commit(ctx)
+	}
+
+	log.Printf("Successfully fetched and saved %d articles.", saved.Written)
+
+	return result, nil
+}
+
+// ドキュメントIDが同じ記事を1件にまとめる。最初に取得した記事を残し、タグは和集合、いいね数は最大値にする
+func mergeDuplicateArticles(articles []model.Article) []model.Article {
+	merged := make([]model.Article, 0, len(articles))
+	index := make(map[string]int, len(articles))
+	for _, a := range articles {
+		id := a.DocumentID()
+		i, ok := index[id]
+		if !ok {
+			index[id] = len(merged)
+			a.Tags = slices.Clone(a.Tags)
+			merged = append(merged, a)
+			continue
 		}
-		allArticles = append(allArticles, qiitaArticles...)
+		m := &merged[i]
+		for _, tag := range a.Tags {
+			if !slices.Contains(m.Tags, tag) {
+				m.Tags = append(m.Tags, tag)
+			}
+		}
+		m.Likes = max(m.Likes, a.Likes)
+	}
+	return merged
+}
 
-		zennArticles, err := fetcher.FetchZennArticles(tag)
-		if err != nil {
-			// エラーをログに出力するなどして、処理を続行
-			fmt.Printf("Error fetching Zenn articles for tag %s: %v\n", tag, err)
+// 取得の進捗（検証子など）を保存する。保存に失敗しても次回の取得で同じ記事を取り直すだけのため、ログに出力して続行する
+func (s *ArticleService) commit(ctx context.Context) {
+	for _, f := range s.fetchers {
+		if c, ok := f.(fetcher.Committer); ok {
+			if err := c.Commit(ctx); err != nil {
+				log.Printf("Error committing %s fetch progress: %v", f.Name(), err)
+			}
 		}
-		allArticles = append(allArticles, zennArticles...)
 	}
+}
 
-	// TODO: 取得した記事の重複排除やソーティングを行う
+// OGPのないページを再び確認するまでの期間
+const ogpRecheckInterval = 7 * 24 * time.Hour
 
-	// リポジトリに保存
-	err := s.repo.SaveArticles(ctx, allArticles)
+// 記事に情報を付与する。付与は必須ではないため、保存の時間を残すよう期限までの残り時間の半分で打ち切る
+// 保存済みの記事にOGP情報がある場合や、OGPのないページを最近確認した場合はそれを使い、記事ページを取得し直さない
+func (s *ArticleService) enrich(ctx context.Context, articles []model.Article) {
+	ids := make([]string, len(articles))
+	for i, a := range articles {
+		ids[i] = a.DocumentID()
+	}
+	stored, err := s.repo.GetArticlesByIDs(ctx, ids)
 	if err != nil {
-		return fmt.Errorf("failed to save articles to repository: %w", err)
+		// 保存済みの情報を使えなくても、すべての記事に付与すればよいため続行する
+		log.Printf("Error loading stored articles for enrichment: %v", err)
 	}
 
-	fmt.Printf("Successfully fetched and saved %d articles.\n", len(allArticles))
+	var targets []int
+	for i := range articles {
+		prev, ok := stored[ids[i]]
+		if ok && (prev.ThumbnailURL != "" || prev.SiteName != "" || time.Since(prev.OGPCheckedAt) < ogpRecheckInterval) {
+			articles[i].ThumbnailURL = prev.ThumbnailURL
+			articles[i].Description = prev.Description
+			articles[i].SiteName = prev.SiteName
+			articles[i].OGPCheckedAt = prev.OGPCheckedAt
+			continue
+		}
+		targets = append(targets, i)
+	}
+	if len(targets) == 0 {
+		return
+	}
 
-	return nil
+	enrichCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		enrichCtx, cancel = context.WithTimeout(ctx, time.Until(deadline)/2)
+		defer cancel()
+	}
+	pending := make([]model.Article, len(targets))
+	for j, i := range targets {
+		pending[j] = articles[i]
+	}
+	s.enricher.Enrich(enrichCtx, pending)
+	for j, i := range targets {
+		articles[i] = pending[j]
+	}
 }
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/fetcher"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/repository"
+)
+
+func TestMergeDuplicateArticles(t *testing.T) {
+	articles := []model.Article{
+		{ID: "qiita-1", Title: "Go入門", Likes: 10, Tags: []string{"Go"}},
+		{ID: "zenn-1", Title: "Rust入門", Likes: 5, Tags: []string{"Rust"}},
+		{ID: "qiita-1", Title: "Go入門", Likes: 12, Tags: []string{"Go", "Docker"}},
+		{URL: "https://example.com/a", Likes: 1, Tags: []string{"Go"}},
+		{URL: "https://example.com/a", Likes: 1, Tags: []string{"AWS"}},
+	}
+
+	got := mergeDuplicateArticles(articles)
+	if len(got) != 3 {
+		t.Fatalf("mergeDuplicateArticles() returned %d articles, want 3", len(got))
+	}
+	want := []struct {
+		id    string
+		likes int
+		tags  []string
+	}{
+		{"qiita-1", 12, []string{"Go", "Docker"}},
+		{"zenn-1", 5, []string{"Rust"}},
+		{"https://example.com/a", 1, []string{"Go", "AWS"}},
+	}
+	for i, w := range want {
+		if got[i].DocumentID() != w.id || got[i].Likes != w.likes || !slices.Equal(got[i].Tags, w.tags) {
+			t.Errorf("article %d = {%s %d %v}, want {%s %d %v}", i, got[i].DocumentID(), got[i].Likes, got[i].Tags, w.id, w.likes, w.tags)
+		}
+	}
+	// 元の記事のタグは変更しない
+	if !slices.Equal(articles[0].Tags, []string{"Go"}) {
+		t.Errorf("input tags were modified: %v", articles[0].Tags)
+	}
+}
+
+type stubFetcher struct {
+	articles []model.Article
+	commits  int
+}
+
+func (f *stubFetcher) Name() string { return "stub" }
+
+func (f *stubFetcher) Fetch(ctx context.Context, tag string) ([]model.Article, error) {
+	return f.articles, nil
+}
+
+func (f *stubFetcher) Commit(ctx context.Context) error {
+	f.commits++
+	return nil
+}
+
+type stubArticleRepository struct {
+	failed int
+}
+
+func (r *stubArticleRepository) SaveArticles(ctx context.Context, articles []model.Article) (model.SaveResult, error) {
+	return model.SaveResult{Written: len(articles) - r.failed, Failed: r.failed}, nil
+}
+
+func (r *stubArticleRepository) GetArticles(ctx context.Context, query model.ArticleQuery) (model.ArticlePage, error) {
+	return model.ArticlePage{}, nil
+}
+
+func (r *stubArticleRepository) GetArticlesByIDs(ctx context.Context, ids []string) (map[string]model.Article, error) {
+	return nil, nil
+}
+
+func TestFetchAndSaveArticlesCommitsAfterSave(t *testing.T) {
+	tests := []struct {
+		name        string
+		articles    []model.Article
+		failed      int
+		wantCommits int
+	}{
+		{name: "保存に成功", articles: []model.Article{{ID: "a", Title: "Go"}}, wantCommits: 1},
+		{name: "保存する記事なし", wantCommits: 1},
+		{name: "一部の保存に失敗", articles: []model.Article{{ID: "a"}, {ID: "b"}}, failed: 1, wantCommits: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &stubFetcher{articles: tt.articles}
+			s := NewArticleService(&stubArticleRepository{failed: tt.failed}, ArticleServiceConfig{Fetchers: []fetcher.Fetcher{f}})
+			if _, err := s.FetchAndSaveArticles(context.Background(), []string{"Go"}); err != nil {
+				t.Fatalf("FetchAndSaveArticles() error = %v", err)
+			}
+			if f.commits != tt.wantCommits {
+				t.Errorf("Commit called %d times, want %d", f.commits, tt.wantCommits)
+			}
+		})
+	}
+}
+
+type stubEnricher struct {
+	urls  []string
+	noOGP bool // ページにOGPタグがない
+}
+
+func (e *stubEnricher) Enrich(ctx context.Context, articles []model.Article) {
+	for i := range articles {
+		e.urls = append(e.urls, articles[i].URL)
+		articles[i].OGPCheckedAt = time.Now()
+		if e.noOGP {
+			continue
+		}
+		articles[i].ThumbnailURL = articles[i].URL + "/ogp.png"
+		articles[i].SiteName = "Scraped"
+	}
+}
+
+func TestFetchAndSaveArticlesSkipsEnrichedArticles(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryArticleRepository()
+	if _, err := repo.SaveArticles(ctx, []model.Article{
+		{ID: "stored", URL: "https://example.com/stored", ThumbnailURL: "https://example.com/stored.png", SiteName: "Stored"},
+		{ID: "no-ogp", URL: "https://example.com/no-ogp"},
+		// OGPのないページを最近確認した記事と、確認してから期間が過ぎた記事
+		{ID: "checked", URL: "https://example.com/checked", OGPCheckedAt: time.Now().Add(-time.Hour)},
+		{ID: "stale", URL: "https://example.com/stale", OGPCheckedAt: time.Now().Add(-ogpRecheckInterval - time.Hour)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &stubFetcher{articles: []model.Article{
+		{ID: "stored", URL: "https://example.com/stored", Title: "Go"},
+		{ID: "no-ogp", URL: "https://example.com/no-ogp", Title: "Go"},
+		{ID: "checked", URL: "https://example.com/checked", Title: "Go"},
+		{ID: "stale", URL: "https://example.com/stale", Title: "Go"},
+		{ID: "new", URL: "https://example.com/new", Title: "Go"},
+	}}
+	enricher := &stubEnricher{}
+	s := NewArticleService(repo, ArticleServiceConfig{Fetchers: []fetcher.Fetcher{f}, Enricher: enricher})
+	if _, err := s.FetchAndSaveArticles(ctx, []string{"Go"}); err != nil {
+		t.Fatalf("FetchAndSaveArticles() error = %v", err)
+	}
+
+	if want := []string{"https://example.com/no-ogp", "https://example.com/stale", "https://example.com/new"}; !slices.Equal(enricher.urls, want) {
+		t.Errorf("enriched URLs = %v, want %v", enricher.urls, want)
+	}
+	saved, err := repo.GetArticlesByIDs(ctx, []string{"stored", "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := saved["stored"]; got.ThumbnailURL != "https://example.com/stored.png" || got.SiteName != "Stored" {
+		t.Errorf("stored article OGP = {%q %q}, want stored values", got.ThumbnailURL, got.SiteName)
+	}
+	if got := saved["new"]; got.SiteName != "Scraped" {
+		t.Errorf("new article SiteName = %q, want Scraped", got.SiteName)
+	}
+}
+
+func TestFetchAndSaveArticlesRemembersPagesWithoutOGP(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryArticleRepository()
+	f := &stubFetcher{articles: []model.Article{{ID: "a", URL: "https://example.com/a", Title: "Go"}}}
+
+	// 再起動しても、OGPのないページを確認したことは保存した記事から分かる
+	for run := range 2 {
+		enricher := &stubEnricher{noOGP: true}
+		s := NewArticleService(repo, ArticleServiceConfig{Fetchers: []fetcher.Fetcher{f}, Enricher: enricher})
+		if _, err := s.FetchAndSaveArticles(ctx, []string{"Go"}); err != nil {
+			t.Fatalf("FetchAndSaveArticles() error = %v", err)
+		}
+		if want := 1 - run; len(enricher.urls) != want {
+			t.Errorf("run %d enriched %d articles, want %d", run+1, len(enricher.urls), want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/fetcher"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// fetchOutcome はソース×タグ1件分の取得結果です。
+type fetchOutcome struct {
+	articles []model.Article
+	err      error
+}
+
+// fetchAll はすべてのソース×タグの組み合わせを最大workers個並行して取得します。
+// 結果は fetchers[i] × tags[j] の結果が outcomes[i*len(tags)+j] となるように返します。
+// ソースごとのホストへの同時接続数はHTTPクライアント側（fetcher.NewHTTPClient）で制限します。
+// 結果はhealthに記録し、停止中のソース×タグはerrCircuitOpenとして取得をスキップします。
+func fetchAll(ctx context.Context, fetchers []fetcher.Fetcher, tags []string, workers int, health *HealthTracker) []fetchOutcome {
+	outcomes := make([]fetchOutcome, len(fetchers)*len(tags))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(outcomes)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				f, tag := fetchers[idx/len(tags)], tags[idx%len(tags)]
+				// 各ワーカーは自分のインデックスにのみ書き込むためロックは不要
+				if err := ctx.Err(); err != nil {
+					outcomes[idx] = fetchOutcome{err: err}
+					continue
+				}
+				if !health.Allow(f.Name(), tag) {
+					outcomes[idx] = fetchOutcome{err: errCircuitOpen}
+					continue
+				}
+				articles, err := f.Fetch(ctx, tag)
+				outcomes[idx] = fetchOutcome{articles: articles, err: err}
+
+				switch {
+				case err == nil || errors.Is(err, fetcher.ErrNotModified):
+					health.RecordSuccess(f.Name(), tag)
+				case ctx.Err() != nil:
+					// 全体のタイムアウトやキャンセルはソースの障害として数えない
+				default:
+					health.RecordFailure(f.Name(), tag, err)
+				}
+			}
+		}()
+	}
+
+	for idx := range outcomes {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	return outcomes
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/fetcher"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+// poolFetcher は同時に実行中のFetchの数を記録するFetcherです。
+type poolFetcher struct {
+	name  string
+	delay time.Duration
+	fail  map[string]bool // 失敗させるタグ
+
+	inFlight    *atomic.Int32
+	maxInFlight *atomic.Int32
+}
+
+func (f *poolFetcher) Name() string { return f.name }
+
+func (f *poolFetcher) Fetch(ctx context.Context, tag string) ([]model.Article, error) {
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		m := f.maxInFlight.Load()
+		if n <= m || f.maxInFlight.CompareAndSwap(m, n) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if f.fail[tag] {
+		return nil, errors.New("fetch failed")
+	}
+	return []model.Article{{ID: f.name + "-" + tag}}, nil
+}
+
+// 終了していないゴルーチンがないことを確認する
+func checkNoGoroutineLeak(t *testing.T, before int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Errorf("goroutines = %d, want at most %d", runtime.NumGoroutine(), before)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFetchAllResultsAndConcurrency(t *testing.T) {
+	before := runtime.NumGoroutine()
+	var inFlight, maxInFlight atomic.Int32
+	fetchers := []fetcher.Fetcher{
+		&poolFetcher{name: "a", delay: 10 * time.Millisecond, fail: map[string]bool{"Rust": true}, inFlight: &inFlight, maxInFlight: &maxInFlight},
+		&poolFetcher{name: "b", delay: 10 * time.Millisecond, inFlight: &inFlight, maxInFlight: &maxInFlight},
+	}
+	tags := []string{"Go", "Rust", "Python"}
+
+	const workers = 2
+	outcomes := fetchAll(context.Background(), fetchers, tags, workers, NewHealthTracker(0, 0))
+
+	if got := maxInFlight.Load(); got > workers {
+		t.Errorf("max concurrent fetches = %d, want at most %d", got, workers)
+	}
+	if len(outcomes) != len(fetchers)*len(tags) {
+		t.Fatalf("got %d outcomes, want %d", len(outcomes), len(fetchers)*len(tags))
+	}
+	// 結果はfetchers[i] × tags[j] の位置に入り、失敗は他の結果に影響しない
+	for i, f := range fetchers {
+		for j, tag := range tags {
+			o := outcomes[i*len(tags)+j]
+			if f.Name() == "a" && tag == "Rust" {
+				if o.err == nil {
+					t.Errorf("outcome %s/%s error = nil, want error", f.Name(), tag)
+				}
+				continue
+			}
+			if o.err != nil || len(o.articles) != 1 || o.articles[0].ID != fmt.Sprintf("%s-%s", f.Name(), tag) {
+				t.Errorf("outcome %s/%s = %+v", f.Name(), tag, o)
+			}
+		}
+	}
+	checkNoGoroutineLeak(t, before)
+}
+
+func TestFetchAllCancellation(t *testing.T) {
+	before := runtime.NumGoroutine()
+	var inFlight, maxInFlight atomic.Int32
+	fetchers := []fetcher.Fetcher{
+		&poolFetcher{name: "slow", delay: time.Hour, inFlight: &inFlight, maxInFlight: &maxInFlight},
+	}
+	tags := []string{"Go", "Rust", "Python", "Docker"}
+	health := NewHealthTracker(1, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	outcomes := fetchAll(ctx, fetchers, tags, 2, health)
+
+	// キャンセルされた取得と、まだ開始していなかった取得のどちらも結果に含まれる
+	for j, o := range outcomes {
+		if !errors.Is(o.err, context.DeadlineExceeded) {
+			t.Errorf("outcome %s error = %v, want %v", tags[j], o.err, context.DeadlineExceeded)
+		}
+	}
+	// キャンセルはソースの障害として数えない
+	for _, tag := range tags {
+		if !health.Allow("slow", tag) {
+			t.Errorf("Allow(%s) = false after cancellation, want true", tag)
+		}
+	}
+	checkNoGoroutineLeak(t, before)
+}
+
+func TestFetchAllSkipsOpenCircuit(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	f := &poolFetcher{name: "a", inFlight: &inFlight, maxInFlight: &maxInFlight}
+	health := NewHealthTracker(1, time.Hour)
+	health.RecordFailure("a", "Rust", errors.New("fetch failed"))
+
+	outcomes := fetchAll(context.Background(), []fetcher.Fetcher{f}, []string{"Go", "Rust"}, 4, health)
+	if outcomes[0].err != nil {
+		t.Errorf("Go outcome error = %v, want nil", outcomes[0].err)
+	}
+	if !errors.Is(outcomes[1].err, errCircuitOpen) {
+		t.Errorf("Rust outcome error = %v, want %v", outcomes[1].err, errCircuitOpen)
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+)
+
+const (
+	// DefaultCircuitThreshold は取得を一時停止するまでの連続失敗回数のデフォルト値です。
+	DefaultCircuitThreshold = 3
+	// DefaultCircuitCooldown は取得を一時停止する時間のデフォルト値です。
+	DefaultCircuitCooldown = 10 * time.Minute
+)
+
+// errCircuitOpen はソース×タグの取得を一時停止しているためスキップしたことを表します。
+var errCircuitOpen = errors.New("source is temporarily disabled for this tag after repeated failures")
+
+// HealthTracker はソース×タグごとの稼働状況を記録し、失敗が続いた組み合わせの取得を一定時間停止します（サーキットブレーカー）。
+// 存在しないタグなど一部のタグの失敗で、ソースの他のタグの取得は止めません。
+// 停止時間が過ぎると1件だけ試行し、成功すると再開、失敗するとすぐに停止します。
+type HealthTracker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	sources  map[string]*model.SourceHealth // ソースごとの最終成功・失敗日時
+	circuits map[circuitKey]*circuit
+}
+
+type circuitKey struct {
+	source string
+	tag    string
+}
+
+// circuit はソース×タグ1件分の停止状態です。
+type circuit struct {
+	failures  int        // 連続した取得失敗の回数
+	openUntil *time.Time // 取得を停止している期限（nilの場合は停止していない）
+	halfOpen  bool       // 停止時間を過ぎて試行している
+}
+
+// NewHealthTracker はHealthTrackerの新しいインスタンスを作成します。
+// threshold回連続で失敗したソース×タグはcooldownの間スキップされます。0以下の値はデフォルト値になります。
+func NewHealthTracker(threshold int, cooldown time.Duration) *HealthTracker {
+	if threshold <= 0 {
+		threshold = DefaultCircuitThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCircuitCooldown
+	}
+	return &HealthTracker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		sources:   make(map[string]*model.SourceHealth),
+		circuits:  make(map[circuitKey]*circuit),
+	}
+}
+
+func (t *HealthTracker) source(source string) *model.SourceHealth {
+	st, ok := t.sources[source]
+	if !ok {
+		st = &model.SourceHealth{Source: source}
+		t.sources[source] = st
+	}
+	return st
+}
+
+func (t *HealthTracker) circuit(source, tag string) *circuit {
+	key := circuitKey{source: source, tag: tag}
+	c, ok := t.circuits[key]
+	if !ok {
+		c = &circuit{}
+		t.circuits[key] = c
+	}
+	return c
+}
+
+// Allow はソースからタグの記事を取得してよいかを返します。停止中の場合はfalseを返します。
+// 停止時間を過ぎた後は1件だけtrueを返し、その結果が記録されるまで（最長で停止時間の間）はfalseを返します。
+func (t *HealthTracker) Allow(source, tag string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c := t.circuit(source, tag)
+	if c.openUntil == nil {
+		return true
+	}
+	now := time.Now()
+	if now.Before(*c.openUntil) {
+		return false
+	}
+	// 試行の結果が記録されない（キャンセルされた）場合も、停止時間の後に再び試行できるよう期限を延ばす
+	until := now.Add(t.cooldown)
+	c.openUntil = &until
+	c.halfOpen = true
+	return true
+}
+
+// RecordSuccess は取得の成功を記録し、停止状態を解除します。
+func (t *HealthTracker) RecordSuccess(source, tag string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	t.source(source).LastSuccessAt = &now
+	*t.circuit(source, tag) = circuit{}
+}
+
+// RecordFailure は取得の失敗を記録し、連続失敗回数がしきい値に達した場合や試行に失敗した場合は取得を停止します。
+func (t *HealthTracker) RecordFailure(source, tag string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	st := t.source(source)
+	st.LastFailureAt = &now
+	st.LastError = err.Error()
+
+	c := t.circuit(source, tag)
+	c.failures++
+	if c.halfOpen || c.failures >= t.threshold {
+		until := now.Add(t.cooldown)
+		c.openUntil = &until
+	}
+	c.halfOpen = false
+}
+
+// Status はソースの稼働状況を返します。
+// 連続失敗回数はタグのうち最も多いもの、停止状態はいずれかのタグを停止しているかです。
+func (t *HealthTracker) Status(source string) model.SourceHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	st := *t.source(source)
+	now := time.Now()
+	for key, c := range t.circuits {
+		if key.source != source {
+			continue
+		}
+		st.ConsecutiveFailures = max(st.ConsecutiveFailures, c.failures)
+		// 停止時間を過ぎている・試行中の場合は再開待ちとして表示する
+		if c.openUntil == nil || c.halfOpen || !now.Before(*c.openUntil) {
+			continue
+		}
+		st.CircuitOpen = true
+		st.OpenTags = append(st.OpenTags, key.tag)
+		if st.OpenUntil == nil || c.openUntil.After(*st.OpenUntil) {
+			until := *c.openUntil
+			st.OpenUntil = &until
+		}
+	}
+	sort.Strings(st.OpenTags)
+	return st
+}
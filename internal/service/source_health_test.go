@@ -0,0 +1,115 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+var errFetch = errors.New("fetch failed")
+
+func TestHealthTrackerOpensAfterThreshold(t *testing.T) {
+	h := NewHealthTracker(3, time.Hour)
+
+	for i := range 2 {
+		h.RecordFailure("qiita", "Go", errFetch)
+		if !h.Allow("qiita", "Go") {
+			t.Fatalf("Allow() = false after %d failures, want true", i+1)
+		}
+	}
+	// 成功すると連続失敗回数はリセットされる
+	h.RecordSuccess("qiita", "Go")
+	h.RecordFailure("qiita", "Go", errFetch)
+	h.RecordFailure("qiita", "Go", errFetch)
+	if !h.Allow("qiita", "Go") {
+		t.Fatal("Allow() = false after a success and 2 failures, want true")
+	}
+
+	h.RecordFailure("qiita", "Go", errFetch)
+	if h.Allow("qiita", "Go") {
+		t.Fatal("Allow() = true after 3 consecutive failures, want false")
+	}
+
+	st := h.Status("qiita")
+	if !st.CircuitOpen || st.ConsecutiveFailures != 3 || !slices.Equal(st.OpenTags, []string{"Go"}) || st.LastError != errFetch.Error() {
+		t.Errorf("Status() = %+v, want open for Go with 3 failures", st)
+	}
+}
+
+func TestHealthTrackerTracksTagsSeparately(t *testing.T) {
+	h := NewHealthTracker(2, time.Hour)
+
+	// 存在しないタグの失敗で、同じソースの他のタグは止めない
+	h.RecordFailure("qiita", "NoSuchTag", errFetch)
+	h.RecordFailure("qiita", "NoSuchTag", errFetch)
+	if h.Allow("qiita", "NoSuchTag") {
+		t.Error("Allow(NoSuchTag) = true, want false")
+	}
+	if !h.Allow("qiita", "Go") {
+		t.Error("Allow(Go) = false, want true")
+	}
+	if !h.Allow("zenn", "NoSuchTag") {
+		t.Error("Allow() for another source = false, want true")
+	}
+}
+
+func TestHealthTrackerHalfOpenAfterCooldown(t *testing.T) {
+	const cooldown = 50 * time.Millisecond
+	h := NewHealthTracker(1, cooldown)
+
+	h.RecordFailure("qiita", "Go", errFetch)
+	if h.Allow("qiita", "Go") {
+		t.Fatal("Allow() = true during cooldown, want false")
+	}
+
+	time.Sleep(cooldown)
+	// 停止時間を過ぎると1件だけ試行する
+	if !h.Allow("qiita", "Go") {
+		t.Fatal("Allow() = false after cooldown, want a probe")
+	}
+	if h.Allow("qiita", "Go") {
+		t.Fatal("Allow() = true while probing, want false")
+	}
+	if st := h.Status("qiita"); st.CircuitOpen {
+		t.Errorf("Status().CircuitOpen = true while probing, want false")
+	}
+
+	// 試行に失敗すると、しきい値に関係なくすぐに停止する
+	h.RecordFailure("qiita", "Go", errFetch)
+	if h.Allow("qiita", "Go") {
+		t.Fatal("Allow() = true after a failed probe, want false")
+	}
+
+	time.Sleep(cooldown)
+	if !h.Allow("qiita", "Go") {
+		t.Fatal("Allow() = false after the second cooldown, want a probe")
+	}
+	// 試行に成功すると再開する
+	h.RecordSuccess("qiita", "Go")
+	for range 2 {
+		if !h.Allow("qiita", "Go") {
+			t.Fatal("Allow() = false after a successful probe, want true")
+		}
+	}
+	if st := h.Status("qiita"); st.CircuitOpen || st.ConsecutiveFailures != 0 || st.LastSuccessAt == nil {
+		t.Errorf("Status() = %+v, want closed after recovery", st)
+	}
+}
+
+func TestHealthTrackerProbeWithoutResult(t *testing.T) {
+	const cooldown = 50 * time.Millisecond
+	h := NewHealthTracker(1, cooldown)
+
+	h.RecordFailure("qiita", "Go", errFetch)
+	time.Sleep(cooldown)
+	if !h.Allow("qiita", "Go") {
+		t.Fatal("Allow() = false after cooldown, want a probe")
+	}
+
+	// 試行の結果が記録されない（キャンセルされた）場合も、停止時間の後に再び試行する
+	time.Sleep(cooldown)
+	if !h.Allow("qiita", "Go") {
+		t.Fatal("Allow() = false after an abandoned probe, want another probe")
+	}
+}
@@ -4,22 +4,29 @@ import (
 	"context"
 
 	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/model"
+	"github.com/iwatsukayugaku/my-tech-articles-app/backend/internal/tagnorm"
 )
 
 // UserRepository はユーザーデータへのアクセスインターフェースです。
 type UserRepository interface {
 	GetUser(ctx context.Context, userID string) (*model.User, error)
 	UpdateUserTags(ctx context.Context, userID string, tags []string) error
+	UpdateUserLang(ctx context.Context, userID string, lang string) error
 }
 
 // UserService はユーザー関連のビジネスロジックを扱います。
 type UserService struct {
 	repo UserRepository
+	tags *tagnorm.Dictionary
 }
 
 // NewUserService はUserServiceの新しいインスタンスを作成します。
-func NewUserService(repo UserRepository) *UserService {
-	return &UserService{repo: repo}
+// tagsはユーザーのタグを正規化する辞書で、nilの場合は組み込みの辞書を使用します。
+func NewUserService(repo UserRepository, tags *tagnorm.Dictionary) *UserService {
+	if tags == nil {
+		tags = tagnorm.Default()
+	}
+	return &UserService{repo: repo, tags: tags}
 }
 
 // GetUser はユーザー情報を取得します。
@@ -28,8 +35,20 @@ func (s *UserService) GetUser(ctx context.Context, userID string) (*model.User,
 	return s.repo.GetUser(ctx, userID)
 }
 
-// UpdateUserTags はユーザーのタグを更新します。
-func (s *UserService) UpdateUserTags(ctx context.Context, userID string, tags []string) error {
-	// リポジトリを使ってユーザーのタグを更新するロジックを実装
-	return s.repo.UpdateUserTags(ctx, userID, tags)
+// UpdateUserTags はユーザーのタグを正規化して更新し、保存したタグを返します。
+func (s *UserService) UpdateUserTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	// 記事のタグと一致するよう、正規のタグ名に揃える
+	normalized := s.tags.NormalizeAll(tags)
+	if normalized == nil {
+		normalized = []string{}
+	}
+	if err := s.repo.UpdateUserTags(ctx, userID, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// UpdateUserLang はユーザーの優先言語を更新します。
+func (s *UserService) UpdateUserLang(ctx context.Context, userID string, lang string) error {
+	return s.repo.UpdateUserLang(ctx, userID, lang)
 }
@@ -0,0 +1,110 @@
+// Package tagnorm はソースごとに表記の異なるタグを正規のタグ名に揃えます。
+package tagnorm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// defaultAliases は組み込みの辞書です（正規のタグ名 -> 別名）。
+// 正規のタグ名自体も大文字小文字を区別せずに一致します。
+var defaultAliases = map[string][]string{
+	"Go":             {"golang", "go言語"},
+	"Python":         {"python3"},
+	"JavaScript":     {"js"},
+	"TypeScript":     {"ts"},
+	"React":          {"reactjs", "react.js"},
+	"Vue":            {"vue.js", "vuejs", "vue3"},
+	"Next.js":        {"nextjs"},
+	"Node.js":        {"nodejs"},
+	"Nuxt":           {"nuxt.js", "nuxtjs"},
+	"Docker":         {},
+	"Kubernetes":     {"k8s"},
+	"AWS":            {"amazon web services"},
+	"GCP":            {"google cloud", "googlecloud"},
+	"Firebase":       {},
+	"Rust":           {"rustlang"},
+	"Ruby on Rails":  {"rails", "rubyonrails"},
+	"C#":             {"csharp"},
+	"C++":            {"cpp"},
+	"PostgreSQL":     {"postgres"},
+	"機械学習":           {"machinelearning", "machine learning"},
+	"Tailwind CSS":   {"tailwindcss"},
+	"GitHub Actions": {"githubactions"},
+}
+
+// Dictionary は正規のタグ名と別名の辞書です。
+type Dictionary struct {
+	canonical map[string]string // 小文字化した別名（および正規名） -> 正規のタグ名
+}
+
+// New は正規のタグ名 -> 別名の対応から辞書を作成します。
+func New(aliases map[string][]string) *Dictionary {
+	d := &Dictionary{canonical: make(map[string]string)}
+	d.add(aliases)
+	return d
+}
+
+// Default は組み込みの辞書を返します。
+func Default() *Dictionary {
+	return New(defaultAliases)
+}
+
+// Load は組み込みの辞書に、JSONファイル（{"正規名": ["別名", ...]}）の内容を追加した辞書を返します。
+// pathが空の場合は組み込みの辞書を返します。
+func Load(path string) (*Dictionary, error) {
+	d := Default()
+	if path == "" {
+		return d, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tag dictionary: %w", err)
+	}
+	var aliases map[string][]string
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse tag dictionary: %w", err)
+	}
+	d.add(aliases)
+	return d, nil
+}
+
+func (d *Dictionary) add(aliases map[string][]string) {
+	for name, list := range aliases {
+		name = strings.TrimSpace(name)
+		d.canonical[key(name)] = name
+		for _, alias := range list {
+			d.canonical[key(alias)] = name
+		}
+	}
+}
+
+// 照合用のキー（前後の空白を除き、連続する空白をまとめて小文字化）
+func key(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+// Normalize はタグを正規のタグ名に変換します。辞書にないタグは前後の空白を除いてそのまま返します。
+func (d *Dictionary) Normalize(tag string) string {
+	if name, ok := d.canonical[key(tag)]; ok {
+		return name
+	}
+	return strings.TrimSpace(tag)
+}
+
+// NormalizeAll はタグの一覧を正規のタグ名に変換し、重複と空のタグを取り除きます。
+func (d *Dictionary) NormalizeAll(tags []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, t := range tags {
+		t = d.Normalize(t)
+		if t == "" || seen[key(t)] {
+			continue
+		}
+		seen[key(t)] = true
+		out = append(out, t)
+	}
+	return out
+}
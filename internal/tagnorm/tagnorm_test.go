@@ -0,0 +1,79 @@
+package tagnorm
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	dict := Default()
+	tests := []struct {
+		name string
+		tag  string
+		want string
+	}{
+		{name: "別名", tag: "golang", want: "Go"},
+		{name: "日本語の別名", tag: "go言語", want: "Go"},
+		{name: "大文字小文字を区別しない", tag: "GoLang", want: "Go"},
+		{name: "正規名の大文字小文字", tag: "javascript", want: "JavaScript"},
+		{name: "前後と連続する空白", tag: "  machine   learning ", want: "機械学習"},
+		{name: "記号を含む別名", tag: "React.js", want: "React"},
+		{name: "辞書にないタグは空白のみ除く", tag: " Elixir ", want: "Elixir"},
+		{name: "空のタグ", tag: "  ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dict.Normalize(tt.tag); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeAll(t *testing.T) {
+	dict := Default()
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{name: "別名と正規名の重複を除く", tags: []string{"golang", "Go", "go言語"}, want: []string{"Go"}},
+		{name: "辞書にないタグは大文字小文字を区別せずに重複を除く", tags: []string{"Elixir", "elixir"}, want: []string{"Elixir"}},
+		{name: "順序を保つ", tags: []string{"k8s", "js", "Docker"}, want: []string{"Kubernetes", "JavaScript", "Docker"}},
+		{name: "空のタグを除く", tags: []string{"", " ", "ts"}, want: []string{"TypeScript"}},
+		{name: "空の一覧", tags: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dict.NormalizeAll(tt.tags); !slices.Equal(got, tt.want) {
+				t.Errorf("NormalizeAll(%q) = %q, want %q", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliases.json")
+	if err := os.WriteFile(path, []byte(`{"Elixir": ["ex"], "Go": ["gopher"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dict, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// ファイルの別名を追加し、組み込みの別名も残す
+	for tag, want := range map[string]string{"EX": "Elixir", "gopher": "Go", "golang": "Go"} {
+		if got := dict.Normalize(tag); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", tag, got, want)
+		}
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() error = nil for a missing file")
+	}
+}